@@ -0,0 +1,48 @@
+package main
+
+type GSArray struct {
+	elements []any
+}
+
+func NewGSArray(elements []any) *GSArray {
+	return &GSArray{
+		elements,
+	}
+}
+
+func (a GSArray) String() string {
+	elements := IteratorFrom(a.elements)
+	strings := Map(&elements, func(element any, _ int, _ []any) string {
+		return stringify(element)
+	})
+	return "[" + strings.Join(", ") + "]"
+}
+
+func (a GSArray) position(index any) (error, int) {
+	number, ok := index.(int64)
+	if !ok {
+		return NewRuntimeError("Array index must be an integer, got %v", stringify(index)), 0
+	}
+	position := int(number)
+	if position < 0 || position >= len(a.elements) {
+		return NewRuntimeError("Array index %v out of range [0, %v)", position, len(a.elements)), 0
+	}
+	return nil, position
+}
+
+func (a GSArray) get(index any) (error, any) {
+	err, position := a.position(index)
+	if err != nil {
+		return err, nil
+	}
+	return nil, a.elements[position]
+}
+
+func (a GSArray) set(index any, value any) error {
+	err, position := a.position(index)
+	if err != nil {
+		return err
+	}
+	a.elements[position] = value
+	return nil
+}
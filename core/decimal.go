@@ -0,0 +1,244 @@
+package main
+
+import (
+	"math/big"
+	"strings"
+)
+
+const (
+	RoundHalfEven = "halfEven"
+	RoundHalfUp   = "halfUp"
+	RoundHalfDown = "halfDown"
+	RoundUp       = "up"
+	RoundDown     = "down"
+	RoundCeiling  = "ceiling"
+	RoundFloor    = "floor"
+)
+
+var roundingModes = map[string]bool{
+	RoundHalfEven: true,
+	RoundHalfUp:   true,
+	RoundHalfDown: true,
+	RoundUp:       true,
+	RoundDown:     true,
+	RoundCeiling:  true,
+	RoundFloor:    true,
+}
+
+// DecimalContext controls inexact decimal operations: how many fractional
+// digits a division keeps and how the last one is rounded.
+type DecimalContext struct {
+	scale    int32
+	rounding string
+}
+
+func NewDecimalContext() *DecimalContext {
+	return &DecimalContext{
+		20,
+		RoundHalfEven,
+	}
+}
+
+var ten = big.NewInt(10)
+
+// MAX_DECIMAL_SCALE caps how many digits rounding and division may move the
+// decimal point by, so that a huge scale can't hang building powers of ten.
+const MAX_DECIMAL_SCALE = 1 << 16
+
+// checkScale rejects scales beyond MAX_DECIMAL_SCALE in either direction.
+func checkScale(scale int64) error {
+	if scale < -MAX_DECIMAL_SCALE || scale > MAX_DECIMAL_SCALE {
+		return NewRuntimeError("Decimal scale %v is out of range, the limit is %v digits", scale, MAX_DECIMAL_SCALE)
+	}
+	return nil
+}
+
+func powerOfTen(exponent int32) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(exponent)), nil)
+}
+
+// roundQuotient divides numerator by denominator and rounds the result to an
+// integer according to the rounding mode.
+func roundQuotient(numerator *big.Int, denominator *big.Int, rounding string) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+	sign := int64(numerator.Sign() * denominator.Sign())
+	away := new(big.Int).Add(quotient, big.NewInt(sign))
+	half := new(big.Int).Abs(remainder)
+	half.Lsh(half, 1)
+	order := half.Cmp(new(big.Int).Abs(denominator))
+	switch rounding {
+	case RoundUp:
+		return away
+	case RoundCeiling:
+		if sign > 0 {
+			return away
+		}
+	case RoundFloor:
+		if sign < 0 {
+			return away
+		}
+	case RoundHalfUp:
+		if order >= 0 {
+			return away
+		}
+	case RoundHalfDown:
+		if order > 0 {
+			return away
+		}
+	case RoundHalfEven:
+		if order > 0 || (order == 0 && quotient.Bit(0) == 1) {
+			return away
+		}
+	}
+	return quotient
+}
+
+// GSDecimal is an exact base-10 number: unscaled * 10^-scale.
+type GSDecimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+func NewGSDecimal(unscaled *big.Int, scale int32) *GSDecimal {
+	return &GSDecimal{
+		unscaled,
+		scale,
+	}
+}
+
+func DecimalFromInteger(value *big.Int) *GSDecimal {
+	return NewGSDecimal(new(big.Int).Set(value), 0)
+}
+
+func ParseGSDecimal(text string) (error, *GSDecimal) {
+	digits := text
+	scale := int32(0)
+	if point := strings.IndexByte(text, '.'); point >= 0 {
+		digits = text[:point] + text[point+1:]
+		scale = int32(len(text) - point - 1)
+	}
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return NewRuntimeError("Can't parse decimal '%v'", text), nil
+	}
+	return nil, NewGSDecimal(unscaled, scale)
+}
+
+func (d GSDecimal) String() string {
+	digits := new(big.Int).Abs(d.unscaled).String()
+	sign := ""
+	if d.unscaled.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale <= 0 {
+		if d.unscaled.Sign() == 0 {
+			return digits
+		}
+		return sign + digits + strings.Repeat("0", int(-d.scale))
+	}
+	if len(digits) <= int(d.scale) {
+		digits = strings.Repeat("0", int(d.scale)-len(digits)+1) + digits
+	}
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+func (d *GSDecimal) rat() *big.Rat {
+	if d.scale >= 0 {
+		return new(big.Rat).SetFrac(d.unscaled, powerOfTen(d.scale))
+	}
+	return new(big.Rat).SetInt(new(big.Int).Mul(d.unscaled, powerOfTen(-d.scale)))
+}
+
+// rescale returns the same value with the given number of fractional digits,
+// rounding when digits are dropped.
+func (d *GSDecimal) rescale(scale int32, rounding string) (error, *GSDecimal) {
+	if err := checkScale(int64(scale)); err != nil {
+		return err, nil
+	}
+	if scale >= d.scale {
+		unscaled := new(big.Int).Mul(d.unscaled, powerOfTen(scale-d.scale))
+		return nil, NewGSDecimal(unscaled, scale)
+	}
+	unscaled := roundQuotient(d.unscaled, powerOfTen(d.scale-scale), rounding)
+	return nil, NewGSDecimal(unscaled, scale)
+}
+
+// trim drops trailing fractional zeros while keeping at least minScale digits.
+func (d *GSDecimal) trim(minScale int32) *GSDecimal {
+	unscaled := new(big.Int).Set(d.unscaled)
+	scale := d.scale
+	remainder := new(big.Int)
+	for scale > minScale {
+		quotient, rem := new(big.Int).QuoRem(unscaled, ten, remainder)
+		if rem.Sign() != 0 {
+			break
+		}
+		unscaled = quotient
+		scale--
+	}
+	return NewGSDecimal(unscaled, scale)
+}
+
+func alignDecimals(a *GSDecimal, b *GSDecimal) (*big.Int, *big.Int, int32) {
+	scale := max(a.scale, b.scale)
+	lhs := new(big.Int).Mul(a.unscaled, powerOfTen(scale-a.scale))
+	rhs := new(big.Int).Mul(b.unscaled, powerOfTen(scale-b.scale))
+	return lhs, rhs, scale
+}
+
+func (d *GSDecimal) add(other *GSDecimal) *GSDecimal {
+	lhs, rhs, scale := alignDecimals(d, other)
+	return NewGSDecimal(lhs.Add(lhs, rhs), scale)
+}
+
+func (d *GSDecimal) sub(other *GSDecimal) *GSDecimal {
+	lhs, rhs, scale := alignDecimals(d, other)
+	return NewGSDecimal(lhs.Sub(lhs, rhs), scale)
+}
+
+func (d *GSDecimal) mul(other *GSDecimal) *GSDecimal {
+	return NewGSDecimal(new(big.Int).Mul(d.unscaled, other.unscaled), d.scale+other.scale)
+}
+
+func (d *GSDecimal) div(other *GSDecimal, context *DecimalContext) (error, *GSDecimal) {
+	if other.unscaled.Sign() == 0 {
+		return NewDivisionByZeroError(), nil
+	}
+	if err := checkScale(int64(context.scale)); err != nil {
+		return err, nil
+	}
+	numerator := new(big.Int).Set(d.unscaled)
+	denominator := new(big.Int).Set(other.unscaled)
+	shift := context.scale - d.scale + other.scale
+	if shift >= 0 {
+		numerator.Mul(numerator, powerOfTen(shift))
+	} else {
+		denominator.Mul(denominator, powerOfTen(-shift))
+	}
+	quotient := NewGSDecimal(roundQuotient(numerator, denominator, context.rounding), context.scale)
+	return nil, quotient.trim(max(d.scale, other.scale))
+}
+
+// quo returns the integral quotient, rounded with the given mode, and the
+// remainder that goes with it.
+func (d *GSDecimal) quo(other *GSDecimal, rounding string) (error, *GSDecimal, *GSDecimal) {
+	if other.unscaled.Sign() == 0 {
+		return NewDivisionByZeroError(), nil, nil
+	}
+	lhs, rhs, _ := alignDecimals(d, other)
+	quotient := DecimalFromInteger(roundQuotient(lhs, rhs, rounding))
+	return nil, quotient, d.sub(quotient.mul(other))
+}
+
+func (d *GSDecimal) pow(exponent int64) *GSDecimal {
+	unscaled := new(big.Int).Exp(d.unscaled, big.NewInt(exponent), nil)
+	return NewGSDecimal(unscaled, d.scale*int32(exponent))
+}
+
+func (d *GSDecimal) neg() *GSDecimal {
+	return NewGSDecimal(new(big.Int).Neg(d.unscaled), d.scale)
+}
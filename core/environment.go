@@ -1,31 +1,36 @@
 package main
 
-import (
-	u "github.com/core/utils"
-)
-
 type Environment struct {
 	values    map[string]any
+	constants map[string]bool
 	enclosing *Environment
 }
 
 func NewEnvironment(enclosing *Environment) *Environment {
 	values := map[string]any{}
+	constants := map[string]bool{}
 	return &Environment{
 		values,
+		constants,
 		enclosing,
 	}
 }
 
 func (e *Environment) define(name string, value any) {
 	e.values[name] = value
+	delete(e.constants, name)
+}
+
+func (e *Environment) defineConstant(name string, value any) {
+	e.values[name] = value
+	e.constants[name] = true
 }
 
 func (e *Environment) getAt(distance int, name string) (error, any) {
 	ancestor := e.ancestor(distance)
 	value, ok := ancestor.values[name]
 	if !ok {
-		return u.NewError("Undefined variable '" + name + "'."), nil
+		return NewRuntimeError("Undefined variable '%v'.", name), nil
 	}
 	return nil, value
 }
@@ -46,13 +51,16 @@ func (e *Environment) assignAt(distance int, name *Token, value any) {
 func (e *Environment) assign(name *Token, value any) error {
 	_, ok := e.values[name.lexeme]
 	if ok {
+		if e.constants[name.lexeme] {
+			return NewRuntimeError("Can't assign to constant '%v'", name.lexeme)
+		}
 		e.values[name.lexeme] = value
 		return nil
 	}
 	if e.enclosing != nil {
 		return e.enclosing.assign(name, value)
 	}
-	return u.NewError("Undefined variable '" + name.lexeme + "'.")
+	return NewRuntimeError("Undefined variable '%v'.", name.lexeme)
 }
 
 func (e Environment) get(name *Token) (error, any) {
@@ -61,7 +69,7 @@ func (e Environment) get(name *Token) (error, any) {
 		if e.enclosing != nil {
 			return e.enclosing.get(name)
 		}
-		return u.NewError("Undefined variable '" + name.lexeme + "'."), nil
+		return NewRuntimeError("Undefined variable '%v'.", name.lexeme), nil
 	}
 	return nil, value
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"fmt"
+
 	u "github.com/core/utils"
 )
 
@@ -12,36 +14,80 @@ func NewResolveError(message string, args ...any) error {
 	return u.NewError("Resolve error: "+message, args...)
 }
 
+// RuntimeError is an error raised while running a script. line is 0 until the
+// interpreter attaches the line of the expression that failed.
+type RuntimeError struct {
+	message string
+	line    uint
+}
+
 func NewRuntimeError(format string, args ...any) error {
-	return u.NewError("Runtime error: "+format, args...)
+	return RuntimeError{
+		fmt.Sprintf(format, args...),
+		0,
+	}
+}
+
+func (e RuntimeError) Error() string {
+	return "Runtime error: " + e.message
+}
+
+// atLine attaches the line of token to a runtime error that has none yet.
+func atLine(err error, token *Token) error {
+	if runtimeErr, ok := err.(RuntimeError); ok && runtimeErr.line == 0 {
+		runtimeErr.line = token.line
+		return runtimeErr
+	}
+	return err
 }
 
 type BreakError struct {
 	message string
+	label   string
 }
 
 func (e BreakError) Error() string {
 	return e.message
 }
 
-func NewBreakError() BreakError {
+func NewBreakError(label string) BreakError {
 	return BreakError{
 		message: "Break outside loop",
+		label:   label,
 	}
 }
 
+// targets reports whether the jump leaves the loop with the given label. An
+// unlabeled jump targets the innermost loop.
+func (e BreakError) targets(label *Token) bool {
+	return e.label == "" || (label != nil && label.lexeme == e.label)
+}
+
 type ContinueError struct {
 	message string
+	label   string
 }
 
 func (e ContinueError) Error() string {
 	return e.message
 }
 
-func NewContinueError() ContinueError {
+func NewContinueError(label string) ContinueError {
 	return ContinueError{
 		message: "Continue outside loop",
+		label:   label,
+	}
+}
+
+func (e ContinueError) targets(label *Token) bool {
+	return e.label == "" || (label != nil && label.lexeme == e.label)
+}
+
+func labelName(label *Token) string {
+	if label == nil {
+		return ""
 	}
+	return label.lexeme
 }
 
 type ReturnError struct {
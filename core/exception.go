@@ -0,0 +1,85 @@
+package main
+
+import "fmt"
+
+const (
+	ErrorKindError        = "Error"
+	ErrorKindRuntimeError = "RuntimeError"
+)
+
+// GSError is the value a catch clause receives. value holds what was thrown
+// when it was not an error value itself.
+type GSError struct {
+	message string
+	kind    string
+	line    uint
+	value   any
+}
+
+func NewGSError(message string, kind string, line uint, value any) *GSError {
+	return &GSError{
+		message,
+		kind,
+		line,
+		value,
+	}
+}
+
+// errorValue converts an error raised by the interpreter into the value a
+// catch clause receives.
+func errorValue(err error) *GSError {
+	switch option := err.(type) {
+	case ThrowError:
+		return option.value
+	case RuntimeError:
+		return NewGSError(option.message, ErrorKindRuntimeError, option.line, nil)
+	}
+	return NewGSError(err.Error(), ErrorKindError, 0, nil)
+}
+
+func (e GSError) String() string {
+	return e.kind + ": " + e.message
+}
+
+func (e *GSError) get(name *Token) (error, any) {
+	switch name.lexeme {
+	case "message":
+		return nil, e.message
+	case "kind":
+		return nil, e.kind
+	case "line":
+		return nil, int64(e.line)
+	case "value":
+		return nil, e.value
+	}
+	return NewRuntimeError("Undefined property '" + name.lexeme + "'"), nil
+}
+
+// ThrowError carries a thrown value up to the closest try statement.
+type ThrowError struct {
+	value *GSError
+}
+
+func NewThrowError(value *GSError) ThrowError {
+	return ThrowError{
+		value,
+	}
+}
+
+func (e ThrowError) Error() string {
+	location := ""
+	if e.value.line > 0 {
+		location = fmt.Sprintf(" at line %v", e.value.line)
+	}
+	return "Uncaught " + e.value.String() + location
+}
+
+// isControlFlow reports whether err is a break, continue or return travelling
+// through the interpreter, which catch clauses must let pass.
+func isControlFlow(err error) bool {
+	switch err.(type) {
+	case BreakError, ContinueError, ReturnError:
+		return true
+	}
+	return false
+}
@@ -50,6 +50,16 @@ func NewLiteral(value any) *Literal {
 	}
 }
 
+type InterpolatedString struct {
+	parts []Expression
+}
+
+func NewInterpolatedString(parts []Expression) *InterpolatedString {
+	return &InterpolatedString{
+		parts,
+	}
+}
+
 type Unary struct {
 	operator *Token
 	right    Expression
@@ -84,6 +94,46 @@ func NewAssignment(name *Token, value Expression) *Assignment {
 	}
 }
 
+type DestructuringAssignment struct {
+	pattern Pattern
+	value   Expression
+}
+
+func NewDestructuringAssignment(pattern Pattern, value Expression) *DestructuringAssignment {
+	return &DestructuringAssignment{
+		pattern,
+		value,
+	}
+}
+
+type CompoundAssignment struct {
+	target   Expression
+	operator *Token
+	value    Expression
+}
+
+func NewCompoundAssignment(target Expression, operator *Token, value Expression) *CompoundAssignment {
+	return &CompoundAssignment{
+		target,
+		operator,
+		value,
+	}
+}
+
+type Update struct {
+	target   Expression
+	operator *Token
+	prefix   bool
+}
+
+func NewUpdate(target Expression, operator *Token, prefix bool) *Update {
+	return &Update{
+		target,
+		operator,
+		prefix,
+	}
+}
+
 type Logical struct {
 	left     Expression
 	operator *Token
@@ -98,40 +148,75 @@ func NewLogical(left Expression, operator *Token, right Expression) *Logical {
 	}
 }
 
+// Call keeps a name for every argument, nil for positional ones.
 type Call struct {
 	callee    Expression
 	paren     *Token
 	arguments []Expression
+	names     []*Token
 }
 
-func NewCall(callee Expression, paren *Token, arguments []Expression) *Call {
+func NewCall(callee Expression, paren *Token, arguments []Expression, names []*Token) *Call {
 	return &Call{
 		callee,
 		paren,
 		arguments,
+		names,
 	}
 }
 
+// Function keeps a default value for every parameter, nil for required ones.
+// rest is the parameter collecting extra arguments, nil if there is none.
 type Function struct {
 	name       *Token
 	parameters []*Token
+	defaults   []Expression
+	rest       *Token
 	body       []Statement
 }
 
-func NewFunction(name *Token, parameters []*Token, body []Statement) *Function {
+func NewFunction(name *Token, parameters []*Token, defaults []Expression, rest *Token, body []Statement) *Function {
 	return &Function{
 		name,
 		parameters,
+		defaults,
+		rest,
 		body,
 	}
 }
 
+type Spread struct {
+	ellipsis   *Token
+	expression Expression
+}
+
+func NewSpread(ellipsis *Token, expression Expression) *Spread {
+	return &Spread{
+		ellipsis,
+		expression,
+	}
+}
+
+// Callable is anything that can be called. Arguments left out of a call come
+// either past the end of arguments or as missingArgument.
 type Callable interface {
-	arity() int
+	minArity() int
+	maxArity() int
 	call(i *Interpreter, arguments []any) (error, any)
 	String() string
 }
 
+// ParameterizedCallable is a Callable with named parameters, which can be
+// called with named arguments.
+type ParameterizedCallable interface {
+	Callable
+	parameters() []*Token
+}
+
+type MissingArgument struct{}
+
+var missingArgument = MissingArgument{}
+
 type Get struct {
 	name   *Token
 	object any
@@ -159,6 +244,58 @@ func NewSet(name *Token, object Expression,
 	}
 }
 
+type ArrayLiteral struct {
+	elements []Expression
+}
+
+func NewArrayLiteral(elements []Expression) *ArrayLiteral {
+	return &ArrayLiteral{
+		elements,
+	}
+}
+
+type MapLiteral struct {
+	keys   []Expression
+	values []Expression
+}
+
+func NewMapLiteral(keys []Expression, values []Expression) *MapLiteral {
+	return &MapLiteral{
+		keys,
+		values,
+	}
+}
+
+type GetIndex struct {
+	object  Expression
+	bracket *Token
+	index   Expression
+}
+
+func NewGetIndex(object Expression, bracket *Token, index Expression) *GetIndex {
+	return &GetIndex{
+		object,
+		bracket,
+		index,
+	}
+}
+
+type SetIndex struct {
+	object  Expression
+	bracket *Token
+	index   Expression
+	value   Expression
+}
+
+func NewSetIndex(object Expression, bracket *Token, index Expression, value Expression) *SetIndex {
+	return &SetIndex{
+		object,
+		bracket,
+		index,
+		value,
+	}
+}
+
 type ThisExpression struct {
 	keyword *Token
 }
@@ -168,3 +305,95 @@ func NewThisExpression(keyword *Token) *ThisExpression {
 		keyword,
 	}
 }
+
+type SuperExpression struct {
+	keyword *Token
+	method  *Token
+}
+
+func NewSuperExpression(keyword *Token, method *Token) *SuperExpression {
+	return &SuperExpression{
+		keyword,
+		method,
+	}
+}
+
+type MatchArm struct {
+	pattern Pattern
+	guard   Expression
+	body    Expression
+}
+
+func NewMatchArm(pattern Pattern, guard Expression, body Expression) *MatchArm {
+	return &MatchArm{
+		pattern,
+		guard,
+		body,
+	}
+}
+
+type MatchExpression struct {
+	keyword *Token
+	subject Expression
+	arms    []*MatchArm
+}
+
+func NewMatchExpression(keyword *Token, subject Expression, arms []*MatchArm) *MatchExpression {
+	return &MatchExpression{
+		keyword,
+		subject,
+		arms,
+	}
+}
+
+type RangeExpression struct {
+	start    Expression
+	operator *Token
+	end      Expression
+	step     Expression
+}
+
+func NewRangeExpression(start Expression, operator *Token, end Expression, step Expression) *RangeExpression {
+	return &RangeExpression{
+		start,
+		operator,
+		end,
+		step,
+	}
+}
+
+// expressionToken returns the token locating an expression in the source, or
+// nil for expressions without one.
+func expressionToken(expression Expression) *Token {
+	switch option := expression.(type) {
+	case *Binary:
+		return option.operator
+	case *Unary:
+		return option.operator
+	case *Call:
+		return option.paren
+	case *Get:
+		return option.name
+	case *Set:
+		return option.name
+	case *GetIndex:
+		return option.bracket
+	case *SetIndex:
+		return option.bracket
+	case *Variable:
+		return option.name
+	case *Assignment:
+		return option.name
+	case *CompoundAssignment:
+		return option.operator
+	case *Update:
+		return option.operator
+	case *RangeExpression:
+		return option.operator
+	case *MatchExpression:
+		return option.keyword
+	case *SuperExpression:
+		return option.keyword
+	}
+	return nil
+}
@@ -3,39 +3,83 @@ package main
 import "fmt"
 
 type GSFunction struct {
-	declaration *Function
-	closure     *Environment
+	declaration   *Function
+	closure       *Environment
+	isInitializer bool
 }
 
-func NewGSFunction(declaration *Function, closure *Environment) *GSFunction {
+func NewGSFunction(declaration *Function, closure *Environment, isInitializer bool) *GSFunction {
 	return &GSFunction{
 		declaration,
 		closure,
+		isInitializer,
 	}
 }
 
-func (f GSFunction) arity() int {
+func (f GSFunction) minArity() int {
+	for index, defaultValue := range f.declaration.defaults {
+		if defaultValue != nil {
+			return index
+		}
+	}
+	return len(f.declaration.parameters)
+}
+
+func (f GSFunction) maxArity() int {
+	if f.declaration.rest != nil {
+		return MAX_FN_ARGUMENTS_COUNT
+	}
 	return len(f.declaration.parameters)
 }
 
+func (f GSFunction) parameters() []*Token {
+	return f.declaration.parameters
+}
+
 func (f GSFunction) String() string {
 	return fmt.Sprintf("[fn: %v]", f.declaration.name.lexeme)
 }
 
 func (f GSFunction) call(i *Interpreter, arguments []any) (error, any) {
 	environment := NewEnvironment(f.closure)
-	for i := 0; i < len(f.declaration.parameters); i++ {
-		environment.define(f.declaration.parameters[i].lexeme, arguments[i])
+	scope := *i
+	scope.environment = environment
+	for index, parameter := range f.declaration.parameters {
+		if index < len(arguments) && arguments[index] != missingArgument {
+			environment.define(parameter.lexeme, arguments[index])
+			continue
+		}
+		defaultErr, value := scope.evaluate(f.declaration.defaults[index])
+		if defaultErr != nil {
+			return defaultErr, nil
+		}
+		environment.define(parameter.lexeme, value)
+	}
+	if f.declaration.rest != nil {
+		extra := []any{}
+		if len(arguments) > len(f.declaration.parameters) {
+			extra = append(extra, arguments[len(f.declaration.parameters):]...)
+		}
+		environment.define(f.declaration.rest.lexeme, NewGSArray(extra))
 	}
 	err := i.executeBlock(f.declaration.body, environment)
 	if rErr, ok := err.(ReturnError); ok {
+		if f.isInitializer {
+			return f.closure.getAt(0, This)
+		}
 		return nil, rErr.value
 	}
-	return err, nil
+	if err != nil {
+		return err, nil
+	}
+	if f.isInitializer {
+		return f.closure.getAt(0, This)
+	}
+	return nil, nil
 }
 
-func (f GSFunction) bind(instance *GSInstance) *GSFunction {
+func (f GSFunction) bind(this any) *GSFunction {
 	environment := NewEnvironment(f.closure)
-	environment.define(This, instance)
-	return NewGSFunction(f.declaration, environment)
+	environment.define(This, this)
+	return NewGSFunction(f.declaration, environment, f.isInitializer)
 }
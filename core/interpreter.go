@@ -2,36 +2,54 @@ package main
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
 
 	u "github.com/core/utils"
 )
 
-func performBinaryNumberOperation(left any, right any, operation func(lhs float64, rhs float64) any) (error, any) {
-	leftErr, lhs := u.AsFloat(left)
-	if leftErr != nil {
-		return leftErr, nil
+func stringify(value any) string {
+	switch option := value.(type) {
+	case nil:
+		return "null"
+	case int64:
+		return strconv.FormatInt(option, 10)
+	case float64:
+		return formatFloat(option)
+	case Callable:
+		return option.String()
 	}
-	rightErr, rhs := u.AsFloat(right)
-	if rightErr != nil {
-		return rightErr, nil
-	}
-	return nil, operation(lhs, rhs)
+	return fmt.Sprint(value)
 }
 
 type Interpreter struct {
-	globals     *Environment
-	environment *Environment
-	locals      map[Expression]int
+	globals        *Environment
+	environment    *Environment
+	locals         map[Expression]int
+	decimalContext *DecimalContext
 }
 
 func NewInterpreter(locals map[Expression]int) *Interpreter {
 	environment := NewEnvironment(nil)
 	environment.define("clock", Clock{})
+	environment.define("len", Len{})
+	environment.define("has", Has{})
+	environment.define("delete", Delete{})
+	environment.define("keys", Keys{})
+	environment.define("error", NewError{})
+	environment.define("decimal", ToDecimal{})
+	environment.define("round", Round{})
+	environment.define("decimalContext", SetDecimalContext{})
+	environment.define("doc", Doc{})
 	globals := environment
 	return &Interpreter{
 		environment,
 		globals,
 		locals,
+		NewDecimalContext(),
 	}
 }
 
@@ -62,6 +80,9 @@ func (i Interpreter) isEqual(a any, b any) bool {
 	if a == nil || b == nil {
 		return false
 	}
+	if isNumber(a) && isNumber(b) {
+		return numbersEqual(a, b)
+	}
 	return a == b
 }
 
@@ -108,14 +129,14 @@ func (i Interpreter) executeFor(forStatement *ForStatement) error {
 	for i.isTruthy(condition) {
 		loopErr := i.execute(forStatement.body)
 		if loopErr != nil {
-			if _, ok := loopErr.(ContinueError); ok {
+			if continueErr, ok := loopErr.(ContinueError); ok && continueErr.targets(forStatement.label) {
 				err := next()
 				if err != nil {
 					return err
 				}
 				continue
 			}
-			if _, ok := loopErr.(BreakError); ok {
+			if breakErr, ok := loopErr.(BreakError); ok && breakErr.targets(forStatement.label) {
 				break
 			}
 			return loopErr
@@ -130,6 +151,59 @@ func (i Interpreter) executeFor(forStatement *ForStatement) error {
 	return nil
 }
 
+func (i Interpreter) executeForIn(forInStatement *ForInStatement) error {
+	iterableErr, iterable := i.evaluate(forInStatement.iterable)
+	if iterableErr != nil {
+		return iterableErr
+	}
+	iteratorErr, next := i.iterator(iterable)
+	if iteratorErr != nil {
+		return iteratorErr
+	}
+	for {
+		err, value, ok := next()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		environment := NewEnvironment(i.environment)
+		environment.define(forInStatement.variable.lexeme, value)
+		loopErr := i.executeBlock([]Statement{forInStatement.body}, environment)
+		if loopErr != nil {
+			if continueErr, ok := loopErr.(ContinueError); ok && continueErr.targets(forInStatement.label) {
+				continue
+			}
+			if breakErr, ok := loopErr.(BreakError); ok && breakErr.targets(forInStatement.label) {
+				return nil
+			}
+			return loopErr
+		}
+	}
+}
+
+// executeTry runs the catch clause for errors other than break, continue and
+// return, then the finally clause whatever happened. An error raised by the
+// finally clause replaces the pending one.
+func (i *Interpreter) executeTry(tryStatement *TryStatement) error {
+	err := i.executeBlock(tryStatement.body, NewEnvironment(i.environment))
+	if err != nil && tryStatement.catchBody != nil && !isControlFlow(err) {
+		environment := NewEnvironment(i.environment)
+		if tryStatement.catchName != nil {
+			environment.define(tryStatement.catchName.lexeme, errorValue(err))
+		}
+		err = i.executeBlock(tryStatement.catchBody, environment)
+	}
+	if tryStatement.finallyBody != nil {
+		finallyErr := i.executeBlock(tryStatement.finallyBody, NewEnvironment(i.environment))
+		if finallyErr != nil {
+			return finallyErr
+		}
+	}
+	return err
+}
+
 func (i Interpreter) executeWhile(whileStatement *WhileStatement) error {
 	conditionErr, condition := i.evaluate(whileStatement.condition)
 	if conditionErr != nil {
@@ -138,13 +212,12 @@ func (i Interpreter) executeWhile(whileStatement *WhileStatement) error {
 	for i.isTruthy(condition) {
 		loopErr := i.execute(whileStatement.statement)
 		if loopErr != nil {
-			if _, ok := loopErr.(ContinueError); ok {
-				continue
-			}
-			if _, ok := loopErr.(BreakError); ok {
+			if breakErr, ok := loopErr.(BreakError); ok && breakErr.targets(whileStatement.label) {
 				break
 			}
-			return loopErr
+			if continueErr, ok := loopErr.(ContinueError); !ok || !continueErr.targets(whileStatement.label) {
+				return loopErr
+			}
 		}
 		conditionErr, condition = i.evaluate(whileStatement.condition)
 		if conditionErr != nil {
@@ -157,29 +230,90 @@ func (i Interpreter) executeWhile(whileStatement *WhileStatement) error {
 func (i *Interpreter) execute(statement Statement) error {
 	switch option := (statement).(type) {
 	case *StructStatment:
+		var superstruct *GSStruct
+		if option.superstruct != nil {
+			err, value := i.evaluate(option.superstruct)
+			if err != nil {
+				return err
+			}
+			gsStruct, ok := value.(*GSStruct)
+			if !ok {
+				return NewRuntimeError("Superstruct must be a struct")
+			}
+			superstruct = gsStruct
+		}
 		i.environment.define(option.name.lexeme, nil)
+		closure := i.environment
+		if superstruct != nil {
+			closure = NewEnvironment(i.environment)
+			closure.define(Super, superstruct)
+		}
 		methods := map[string]*GSFunction{}
 		for _, method := range option.methods {
-			fn := NewGSFunction(method, i.environment)
+			fn := NewGSFunction(method, closure, method.name.lexeme == InitMethod)
 			methods[method.name.lexeme] = fn
 		}
-		gStruct := NewGSStruct(option.name.lexeme, methods)
+		staticMethods := map[string]*GSFunction{}
+		for _, method := range option.staticMethods {
+			staticMethods[method.name.lexeme] = NewGSFunction(method, closure, false)
+		}
+		getters := map[string]*GSFunction{}
+		for _, getter := range option.getters {
+			getters[getter.name.lexeme] = NewGSFunction(getter, closure, false)
+		}
+		setters := map[string]*GSFunction{}
+		for _, setter := range option.setters {
+			setters[setter.name.lexeme] = NewGSFunction(setter, closure, false)
+		}
+		gStruct := NewGSStruct(option.name.lexeme, superstruct, methods, staticMethods, getters, setters)
 		i.environment.assign(option.name, gStruct)
+		for _, field := range option.staticFields {
+			var value any
+			if field.initializer != nil {
+				err, result := i.evaluate(field.initializer)
+				if err != nil {
+					return err
+				}
+				value = result
+			}
+			gStruct.set(field.pattern.(*BindingPattern).name, value)
+		}
 		return nil
 	case *ReturnStatement:
+		var value any
+		if option.value != nil {
+			err, result := i.evaluate(option.value)
+			if err != nil {
+				return err
+			}
+			value = result
+		}
+		return NewReturnError(value)
+	case *TryStatement:
+		return i.executeTry(option)
+	case *ThrowStatement:
 		err, value := i.evaluate(option.value)
 		if err != nil {
 			return err
 		}
-		return NewReturnError(value)
+		if gsError, ok := value.(*GSError); ok {
+			line := gsError.line
+			if line == 0 {
+				line = option.keyword.line
+			}
+			return NewThrowError(NewGSError(gsError.message, gsError.kind, line, gsError.value))
+		}
+		return NewThrowError(NewGSError(stringify(value), ErrorKindError, option.keyword.line, value))
 	case *BreakStatement:
-		return NewBreakError()
+		return NewBreakError(labelName(option.label))
 	case *ContinueStatement:
-		return NewContinueError()
+		return NewContinueError(labelName(option.label))
 	case *BlockStatement:
 		return i.executeBlock(option.statements, NewEnvironment(i.environment))
 	case *ForStatement:
 		return i.executeFor(option)
+	case *ForInStatement:
+		return i.executeForIn(option)
 	case *WhileStatement:
 		return i.executeWhile(option)
 	case *IfElseStatement:
@@ -209,18 +343,21 @@ func (i *Interpreter) execute(statement Statement) error {
 			}
 			value = result
 		}
-		i.environment.define(option.name.lexeme, value)
-		return nil
+		return i.destructure(option.pattern, value, func(pattern Pattern, value any) error {
+			name := pattern.(*BindingPattern).name.lexeme
+			if option.constant {
+				i.environment.defineConstant(name, value)
+			} else {
+				i.environment.define(name, value)
+			}
+			return nil
+		})
 	case *PrintStatement:
 		err, value := i.evaluate(option.expression)
 		if err != nil {
 			return err
 		}
-		if callee, ok := value.(Callable); ok {
-			fmt.Println(callee.String())
-			return nil
-		}
-		fmt.Println(value)
+		fmt.Println(stringify(value))
 		return nil
 	case *ExpressionStatement:
 		err, _ := i.evaluate(option.expression)
@@ -240,19 +377,461 @@ func (i Interpreter) lookUpVariable(name *Token, expression Expression) (error,
 	}
 }
 
+func (i Interpreter) assignVariable(name *Token, expression Expression, value any) error {
+	distance, ok := i.locals[expression]
+	if ok {
+		i.environment.assignAt(distance, name, value)
+		return nil
+	}
+	return i.globals.assign(name, value)
+}
+
+// destructure matches value against a declaration or assignment pattern and
+// hands every leaf of the pattern with its value to bind.
+func (i Interpreter) destructure(pattern Pattern, value any, bind func(pattern Pattern, value any) error) error {
+	switch option := pattern.(type) {
+	case *ArrayPattern:
+		err, next := i.iterator(value)
+		if err != nil {
+			return err
+		}
+		values := []any{}
+		for option.rest != nil || len(values) <= len(option.elements) {
+			nextErr, element, ok := next()
+			if nextErr != nil {
+				return nextErr
+			}
+			if !ok {
+				break
+			}
+			values = append(values, element)
+		}
+		if len(values) < len(option.elements) {
+			return NewRuntimeError("Not enough values to destructure, expected %v but got %v", len(option.elements), len(values))
+		}
+		if option.rest == nil && len(values) > len(option.elements) {
+			return NewRuntimeError("Too many values to destructure, expected %v", len(option.elements))
+		}
+		for index, element := range option.elements {
+			elementErr := i.destructure(element, values[index], bind)
+			if elementErr != nil {
+				return elementErr
+			}
+		}
+		if option.rest != nil {
+			return i.destructure(option.rest, NewGSArray(values[len(option.elements):]), bind)
+		}
+		return nil
+	case *ObjectPattern:
+		for index, field := range option.fields {
+			var fieldValue any
+			switch object := value.(type) {
+			case *GSInstance:
+				if !object.hasProperty(field.lexeme) {
+					return NewRuntimeError("Undefined property '%v'", field.lexeme)
+				}
+				err, result := object.get(&i, field)
+				if err != nil {
+					return err
+				}
+				fieldValue = result
+			case *GSMap:
+				err, result := object.get(field.lexeme)
+				if err != nil {
+					return err
+				}
+				fieldValue = result
+			default:
+				return NewRuntimeError("Only instances and maps can be destructured by field, got %v", stringify(value))
+			}
+			err := i.destructure(option.values[index], fieldValue, bind)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return bind(pattern, value)
+}
+
+func (i Interpreter) assignTarget(target Expression, value any) error {
+	switch option := target.(type) {
+	case *Variable:
+		return i.assignVariable(option.name, option, value)
+	case *Get:
+		err, object := i.evaluate(option.object)
+		if err != nil {
+			return err
+		}
+		if instance, ok := object.(*GSInstance); ok {
+			return instance.set(&i, option.name, value)
+		}
+		if gsStruct, ok := object.(*GSStruct); ok {
+			gsStruct.set(option.name, value)
+			return nil
+		}
+		return NewRuntimeError("Only instances and structs have property names")
+	case *GetIndex:
+		err, object := i.evaluate(option.object)
+		if err != nil {
+			return err
+		}
+		indexErr, index := i.evaluate(option.index)
+		if indexErr != nil {
+			return indexErr
+		}
+		if array, ok := object.(*GSArray); ok {
+			return array.set(index, value)
+		}
+		if gsMap, ok := object.(*GSMap); ok {
+			return gsMap.set(index, value)
+		}
+		return NewRuntimeError("Only arrays and maps can be indexed")
+	}
+	return NewRuntimeError("Invalid assignment target")
+}
+
+// updateTarget evaluates the parts of an assignment target once, then reads the
+// current value, stores the updated one and returns both.
+func (i Interpreter) updateTarget(expression Expression, target Expression, update func(current any) (error, any)) (error, any, any) {
+	switch option := (target).(type) {
+	case *Variable:
+		err, current := i.lookUpVariable(option.name, expression)
+		if err != nil {
+			return err, nil, nil
+		}
+		updateErr, value := update(current)
+		if updateErr != nil {
+			return updateErr, nil, nil
+		}
+		return i.assignVariable(option.name, expression, value), current, value
+	case *Get:
+		err, object := i.evaluate(option.object)
+		if err != nil {
+			return err, nil, nil
+		}
+		if instance, ok := object.(*GSInstance); ok {
+			getErr, current := instance.get(&i, option.name)
+			if getErr != nil {
+				return getErr, nil, nil
+			}
+			updateErr, value := update(current)
+			if updateErr != nil {
+				return updateErr, nil, nil
+			}
+			return instance.set(&i, option.name, value), current, value
+		}
+		if gsStruct, ok := object.(*GSStruct); ok {
+			getErr, current := gsStruct.get(option.name)
+			if getErr != nil {
+				return getErr, nil, nil
+			}
+			updateErr, value := update(current)
+			if updateErr != nil {
+				return updateErr, nil, nil
+			}
+			gsStruct.set(option.name, value)
+			return nil, current, value
+		}
+		return NewRuntimeError("Only instances and structs have property names"), nil, nil
+	case *GetIndex:
+		err, object := i.evaluate(option.object)
+		if err != nil {
+			return err, nil, nil
+		}
+		indexErr, index := i.evaluate(option.index)
+		if indexErr != nil {
+			return indexErr, nil, nil
+		}
+		if array, ok := object.(*GSArray); ok {
+			getErr, current := array.get(index)
+			if getErr != nil {
+				return getErr, nil, nil
+			}
+			updateErr, value := update(current)
+			if updateErr != nil {
+				return updateErr, nil, nil
+			}
+			return array.set(index, value), current, value
+		}
+		if gsMap, ok := object.(*GSMap); ok {
+			getErr, current := gsMap.get(index)
+			if getErr != nil {
+				return getErr, nil, nil
+			}
+			updateErr, value := update(current)
+			if updateErr != nil {
+				return updateErr, nil, nil
+			}
+			return gsMap.set(index, value), current, value
+		}
+		return NewRuntimeError("Only arrays and maps can be indexed"), nil, nil
+	}
+	return NewRuntimeError("Invalid assignment target"), nil, nil
+}
+
+// matchPattern checks value against pattern, defining the names the pattern
+// binds in environment.
+func (i Interpreter) matchPattern(pattern Pattern, value any, environment *Environment) (error, bool) {
+	switch option := pattern.(type) {
+	case *WildcardPattern:
+		return nil, true
+	case *LiteralPattern:
+		return nil, i.isEqual(option.value, value)
+	case *BindingPattern:
+		environment.define(option.name.lexeme, value)
+		return nil, true
+	case *AlternativePattern:
+		for _, alternative := range option.alternatives {
+			err, matched := i.matchPattern(alternative, value, environment)
+			if err != nil || matched {
+				return err, matched
+			}
+		}
+		return nil, false
+	case *StructPattern:
+		err, callee := i.evaluate(option.name)
+		if err != nil {
+			return err, false
+		}
+		gsStruct, ok := callee.(*GSStruct)
+		if !ok {
+			return NewRuntimeError("'%v' is not a struct", option.name.name.lexeme), false
+		}
+		instance, ok := value.(*GSInstance)
+		if !ok || !instance.gsStruct.inherits(gsStruct) {
+			return nil, false
+		}
+		for index, field := range option.fields {
+			if !instance.hasProperty(field.lexeme) {
+				return nil, false
+			}
+			fieldErr, fieldValue := instance.get(&i, field)
+			if fieldErr != nil {
+				return fieldErr, false
+			}
+			matchErr, matched := i.matchPattern(option.values[index], fieldValue, environment)
+			if matchErr != nil || !matched {
+				return matchErr, false
+			}
+		}
+		return nil, true
+	}
+	return NewRuntimeError("Unknown pattern"), false
+}
+
+func (i Interpreter) evaluateMatch(match *MatchExpression) (error, any) {
+	err, subject := i.evaluate(match.subject)
+	if err != nil {
+		return err, nil
+	}
+	enclosing := i.environment
+	for _, arm := range match.arms {
+		i.environment = NewEnvironment(enclosing)
+		matchErr, matched := i.matchPattern(arm.pattern, subject, i.environment)
+		if matchErr != nil {
+			return matchErr, nil
+		}
+		if !matched {
+			continue
+		}
+		if arm.guard != nil {
+			guardErr, guard := i.evaluate(arm.guard)
+			if guardErr != nil {
+				return guardErr, nil
+			}
+			if !i.isTruthy(guard) {
+				continue
+			}
+		}
+		return i.evaluate(arm.body)
+	}
+	return NewRuntimeError("No arm matches value '%v' and there is no default '_' arm", stringify(subject)), nil
+}
+
+func (i Interpreter) evaluateRange(rangeExpression *RangeExpression) (error, any) {
+	bounds := []int64{0, 0, 1}
+	for index, bound := range []Expression{rangeExpression.start, rangeExpression.end, rangeExpression.step} {
+		if bound == nil {
+			continue
+		}
+		err, value := i.evaluate(bound)
+		if err != nil {
+			return err, nil
+		}
+		integer, ok := value.(int64)
+		if !ok {
+			return NewRuntimeError("Range bounds and step must be integers, got %v", stringify(value)), nil
+		}
+		bounds[index] = integer
+	}
+	if bounds[2] == 0 {
+		return NewRuntimeError("Range step can't be zero"), nil
+	}
+	return nil, NewGSRange(bounds[0], bounds[1], bounds[2], rangeExpression.operator.tokenType == DotDotEqual)
+}
+
+// contains reports whether value is an element of an array or a range, a key
+// of a map or a substring of a string.
+func (i Interpreter) contains(collection any, value any) (error, any) {
+	switch option := collection.(type) {
+	case *GSArray:
+		for _, element := range option.elements {
+			if i.isEqual(element, value) {
+				return nil, true
+			}
+		}
+		return nil, false
+	case *GSMap:
+		return option.has(value)
+	case *GSRange:
+		return nil, option.contains(value)
+	case string:
+		substring, ok := value.(string)
+		if !ok {
+			return NewRuntimeError("Can only look for strings in a string"), nil
+		}
+		return nil, strings.Contains(option, substring)
+	}
+	return NewRuntimeError("Can't look for a value in '%v'", stringify(collection)), nil
+}
+
+func (i Interpreter) slice(value any, gsRange *GSRange) (error, any) {
+	switch option := value.(type) {
+	case *GSArray:
+		err, elements := gsRange.slice(option.elements)
+		if err != nil {
+			return err, nil
+		}
+		return nil, NewGSArray(elements)
+	case string:
+		err, elements := gsRange.slice(characters(option))
+		if err != nil {
+			return err, nil
+		}
+		var result strings.Builder
+		for _, character := range elements {
+			result.WriteString(character.(string))
+		}
+		return nil, result.String()
+	}
+	return NewRuntimeError("Only arrays and strings can be sliced"), nil
+}
+
+// bindArguments places named arguments at the position of their parameter and
+// checks that every required parameter gets a value.
+func (i Interpreter) bindArguments(fn Callable, names []*Token, values []any) (error, []any) {
+	arguments := []any{}
+	for index, value := range values {
+		if names[index] == nil {
+			arguments = append(arguments, value)
+		}
+	}
+	if len(arguments) > fn.maxArity() {
+		if fn.minArity() == fn.maxArity() {
+			return NewRuntimeError("Expected %v arguments but got %v", fn.maxArity(), len(arguments)), nil
+		}
+		return NewRuntimeError("Expected at most %v arguments but got %v", fn.maxArity(), len(arguments)), nil
+	}
+	parameterized, hasParameters := fn.(ParameterizedCallable)
+	if !hasParameters {
+		if len(values) > len(arguments) {
+			return NewRuntimeError("%v doesn't take named arguments", fn), nil
+		}
+		if len(arguments) < fn.minArity() {
+			if fn.minArity() == fn.maxArity() {
+				return NewRuntimeError("Expected %v arguments but got %v", fn.minArity(), len(arguments)), nil
+			}
+			return NewRuntimeError("Expected at least %v arguments but got %v", fn.minArity(), len(arguments)), nil
+		}
+		return nil, arguments
+	}
+	parameters := parameterized.parameters()
+	for index, name := range names {
+		if name == nil {
+			continue
+		}
+		position := slices.IndexFunc(parameters, func(parameter *Token) bool {
+			return parameter.lexeme == name.lexeme
+		})
+		if position < 0 {
+			return NewRuntimeError("Unknown parameter '%v' for %v", name.lexeme, fn), nil
+		}
+		for len(arguments) <= position {
+			arguments = append(arguments, missingArgument)
+		}
+		if arguments[position] != missingArgument {
+			return NewRuntimeError("Parameter '%v' got more than one value", name.lexeme), nil
+		}
+		arguments[position] = values[index]
+	}
+	missing := []string{}
+	for index := 0; index < fn.minArity(); index++ {
+		if index >= len(arguments) || arguments[index] == missingArgument {
+			missing = append(missing, "'"+parameters[index].lexeme+"'")
+		}
+	}
+	if len(missing) > 0 {
+		return NewRuntimeError("Missing argument for parameter %v of %v", strings.Join(missing, ", "), fn), nil
+	}
+	return nil, arguments
+}
+
+// evaluate evaluates an expression and attaches its line to the runtime errors
+// raised while doing so.
 func (i Interpreter) evaluate(expression Expression) (error, any) {
+	err, value := i.evaluateExpression(expression)
+	if err != nil {
+		if token := expressionToken(expression); token != nil {
+			return atLine(err, token), nil
+		}
+	}
+	return err, value
+}
+
+func (i Interpreter) evaluateExpression(expression Expression) (error, any) {
 	switch option := (expression).(type) {
 	case *ThisExpression:
 		return i.lookUpVariable(option.keyword, expression)
+	case *SuperExpression:
+		distance := i.locals[expression]
+		superErr, value := i.environment.getAt(distance, Super)
+		if superErr != nil {
+			return superErr, nil
+		}
+		superstruct := value.(*GSStruct)
+		thisErr, object := i.environment.getAt(distance-1, This)
+		if thisErr != nil {
+			return thisErr, nil
+		}
+		var method *GSFunction
+		switch object.(type) {
+		case *GSInstance:
+			method = superstruct.findMethod(option.method.lexeme)
+		case *GSStruct:
+			method = superstruct.findStaticMethod(option.method.lexeme)
+		default:
+			return NewRuntimeError("Can't use 'super' on '%v'", stringify(object)), nil
+		}
+		if method == nil {
+			return NewRuntimeError("Undefined property '" + option.method.lexeme + "'"), nil
+		}
+		return nil, method.bind(object)
 	case *Get:
 		err, value := i.evaluate(option.object)
 		if err != nil {
 			return err, nil
 		}
 		if instance, ok := value.(*GSInstance); ok {
-			return instance.get(option.name)
+			return instance.get(&i, option.name)
+		}
+		if gsStruct, ok := value.(*GSStruct); ok {
+			return gsStruct.get(option.name)
+		}
+		if gsError, ok := value.(*GSError); ok {
+			return gsError.get(option.name)
 		}
-		return NewRuntimeError("Only instances have property names"), nil
+		return NewRuntimeError("Only instances and structs have property names"), nil
 	case *Set:
 		err, value := i.evaluate(option.object)
 		if err != nil {
@@ -263,12 +842,119 @@ func (i Interpreter) evaluate(expression Expression) (error, any) {
 			if err != nil {
 				return err, nil
 			}
-			instance.set(option.name, value)
+			setErr := instance.set(&i, option.name, value)
+			if setErr != nil {
+				return setErr, nil
+			}
 			return nil, value
 		}
-		return NewRuntimeError("Only instances have property names"), nil
+		if gsStruct, ok := value.(*GSStruct); ok {
+			err, value := i.evaluate(option.value)
+			if err != nil {
+				return err, nil
+			}
+			gsStruct.set(option.name, value)
+			return nil, value
+		}
+		return NewRuntimeError("Only instances and structs have property names"), nil
+	case *ArrayLiteral:
+		elements := []any{}
+		for _, element := range option.elements {
+			spread, isSpread := element.(*Spread)
+			if !isSpread {
+				err, value := i.evaluate(element)
+				if err != nil {
+					return err, nil
+				}
+				elements = append(elements, value)
+				continue
+			}
+			err, iterable := i.evaluate(spread.expression)
+			if err != nil {
+				return err, nil
+			}
+			iteratorErr, next := i.iterator(iterable)
+			if iteratorErr != nil {
+				return iteratorErr, nil
+			}
+			for {
+				nextErr, value, ok := next()
+				if nextErr != nil {
+					return nextErr, nil
+				}
+				if !ok {
+					break
+				}
+				elements = append(elements, value)
+			}
+		}
+		return nil, NewGSArray(elements)
+	case *MapLiteral:
+		gsMap := NewGSMap()
+		for index, keyExpression := range option.keys {
+			keyErr, key := i.evaluate(keyExpression)
+			if keyErr != nil {
+				return keyErr, nil
+			}
+			valueErr, value := i.evaluate(option.values[index])
+			if valueErr != nil {
+				return valueErr, nil
+			}
+			setErr := gsMap.set(key, value)
+			if setErr != nil {
+				return setErr, nil
+			}
+		}
+		return nil, gsMap
+	case *GetIndex:
+		err, value := i.evaluate(option.object)
+		if err != nil {
+			return err, nil
+		}
+		indexErr, index := i.evaluate(option.index)
+		if indexErr != nil {
+			return indexErr, nil
+		}
+		if gsRange, ok := index.(*GSRange); ok {
+			return i.slice(value, gsRange)
+		}
+		if array, ok := value.(*GSArray); ok {
+			return array.get(index)
+		}
+		if gsMap, ok := value.(*GSMap); ok {
+			return gsMap.get(index)
+		}
+		return NewRuntimeError("Only arrays and maps can be indexed"), nil
+	case *SetIndex:
+		err, value := i.evaluate(option.object)
+		if err != nil {
+			return err, nil
+		}
+		indexErr, index := i.evaluate(option.index)
+		if indexErr != nil {
+			return indexErr, nil
+		}
+		valueErr, newValue := i.evaluate(option.value)
+		if valueErr != nil {
+			return valueErr, nil
+		}
+		if array, ok := value.(*GSArray); ok {
+			setErr := array.set(index, newValue)
+			if setErr != nil {
+				return setErr, nil
+			}
+			return nil, newValue
+		}
+		if gsMap, ok := value.(*GSMap); ok {
+			setErr := gsMap.set(index, newValue)
+			if setErr != nil {
+				return setErr, nil
+			}
+			return nil, newValue
+		}
+		return NewRuntimeError("Only arrays and maps can be indexed"), nil
 	case *Function:
-		f := NewGSFunction(option, i.environment)
+		f := NewGSFunction(option, i.environment, false)
 		if option.name.lexeme != AnonymusFunction {
 			i.environment.define(option.name.lexeme, f)
 		}
@@ -279,22 +965,49 @@ func (i Interpreter) evaluate(expression Expression) (error, any) {
 			return err, nil
 		}
 		arguments := []any{}
-		for _, argument := range option.arguments {
-			err, expression := i.evaluate(argument)
+		names := []*Token{}
+		for index, argument := range option.arguments {
+			spread, isSpread := argument.(*Spread)
+			if !isSpread {
+				err, expression := i.evaluate(argument)
+				if err != nil {
+					return err, nil
+				}
+				arguments = append(arguments, expression)
+				names = append(names, option.names[index])
+				continue
+			}
+			err, iterable := i.evaluate(spread.expression)
 			if err != nil {
 				return err, nil
 			}
-			arguments = append(arguments, expression)
+			iteratorErr, next := i.iterator(iterable)
+			if iteratorErr != nil {
+				return iteratorErr, nil
+			}
+			for {
+				nextErr, value, ok := next()
+				if nextErr != nil {
+					return nextErr, nil
+				}
+				if !ok {
+					break
+				}
+				if len(arguments) == MAX_FN_ARGUMENTS_COUNT {
+					return NewRuntimeError("Can't have more than %v arguments", MAX_FN_ARGUMENTS_COUNT), nil
+				}
+				arguments = append(arguments, value)
+				names = append(names, nil)
+			}
 		}
 		if fn, ok := callee.(Callable); ok {
-			argumentsQuantity := len(arguments)
-			arity := fn.arity()
-			if argumentsQuantity != arity {
-				return u.NewError("Expected %v arguments but got %v", arity, argumentsQuantity), nil
+			bindErr, bound := i.bindArguments(fn, names, arguments)
+			if bindErr != nil {
+				return bindErr, nil
 			}
-			return fn.call(&i, arguments)
+			return fn.call(&i, bound)
 		}
-		return u.NewError("Can only call functions"), nil
+		return NewRuntimeError("Can only call functions"), nil
 	case *Logical:
 		err, left := i.evaluate(option.left)
 		if err != nil {
@@ -330,21 +1043,55 @@ func (i Interpreter) evaluate(expression Expression) (error, any) {
 		}
 	case *Variable:
 		return i.lookUpVariable(option.name, expression)
+	case *MatchExpression:
+		return i.evaluateMatch(option)
+	case *RangeExpression:
+		return i.evaluateRange(option)
 	case *Assignment:
 		err, value := i.evaluate(option.value)
 		if err != nil {
 			return err, nil
 		}
-		distance, ok := i.locals[expression]
-		if ok {
-			i.environment.assignAt(distance, option.name, value)
-		} else {
-			assignErr := i.globals.assign(option.name, value)
-			if assignErr != nil {
-				return assignErr, nil
+		assignErr := i.assignVariable(option.name, expression, value)
+		if assignErr != nil {
+			return assignErr, nil
+		}
+		return nil, value
+	case *DestructuringAssignment:
+		err, value := i.evaluate(option.value)
+		if err != nil {
+			return err, nil
+		}
+		destructureErr := i.destructure(option.pattern, value, func(pattern Pattern, value any) error {
+			return i.assignTarget(pattern.(*TargetPattern).target, value)
+		})
+		if destructureErr != nil {
+			return destructureErr, nil
+		}
+		return nil, value
+	case *CompoundAssignment:
+		err, _, value := i.updateTarget(expression, option.target, func(current any) (error, any) {
+			err, value := i.evaluate(option.value)
+			if err != nil {
+				return err, nil
 			}
+			return i.performBinaryOperation(option.operator, current, value)
+		})
+		if err != nil {
+			return err, nil
 		}
 		return nil, value
+	case *Update:
+		err, previous, value := i.updateTarget(expression, option.target, func(current any) (error, any) {
+			return i.performBinaryOperation(option.operator, current, int64(1))
+		})
+		if err != nil {
+			return err, nil
+		}
+		if option.prefix {
+			return nil, value
+		}
+		return nil, previous
 	case *Binary:
 		leftErr, left := i.evaluate(option.left)
 		if leftErr != nil {
@@ -354,60 +1101,7 @@ func (i Interpreter) evaluate(expression Expression) (error, any) {
 		if rightErr != nil {
 			return rightErr, nil
 		}
-		switch operator := option.operator.tokenType; operator {
-		case Greater:
-			return performBinaryNumberOperation(left, right, func(lhs float64, rhs float64) any {
-				return lhs > rhs
-			})
-		case GreaterEqual:
-			return performBinaryNumberOperation(left, right, func(lhs float64, rhs float64) any {
-				return lhs >= rhs
-			})
-		case Less:
-			return performBinaryNumberOperation(left, right, func(lhs float64, rhs float64) any {
-				return lhs < rhs
-			})
-		case LessEqual:
-			return performBinaryNumberOperation(left, right, func(lhs float64, rhs float64) any {
-				return lhs <= rhs
-			})
-		case BangEqual:
-			return nil, !i.isEqual(left, right)
-		case EqualEqual:
-			return nil, i.isEqual(left, right)
-		case Minus:
-			return performBinaryNumberOperation(left, right, func(lhs float64, rhs float64) any {
-				return lhs - rhs
-			})
-		case Star:
-			return performBinaryNumberOperation(left, right, func(lhs float64, rhs float64) any {
-				return lhs * rhs
-			})
-		case Slash:
-			return performBinaryNumberOperation(left, right, func(lhs float64, rhs float64) any {
-				return lhs / rhs
-			})
-		case Plus:
-			if u.IsString(left) && u.IsString(right) {
-				lhs := u.AsString(left)
-				rhs := u.AsString(right)
-				return nil, lhs + rhs
-			}
-			if u.IsFloat(left) && u.IsFloat(right) {
-				leftErr, lhs := u.AsFloat(left)
-				if leftErr != nil {
-					return leftErr, nil
-				}
-				rightErr, rhs := u.AsFloat(right)
-				if rightErr != nil {
-					return rightErr, nil
-				}
-				return nil, lhs + rhs
-			}
-			return u.NewError("Unexpected plus types T1:'%T' T2:'%T'", left, right), nil
-		default:
-			return u.NewError("Unexpected binary operator '%v'", operator), nil
-		}
+		return i.performBinaryOperation(option.operator, left, right)
 	case *Unary:
 		rightErr, right := i.evaluate(option.right)
 		if rightErr != nil {
@@ -415,16 +1109,30 @@ func (i Interpreter) evaluate(expression Expression) (error, any) {
 		}
 		switch operator := option.operator.tokenType; operator {
 		case Minus:
-			err, rhs := u.AsFloat(right)
-			if err != nil {
-				return err, nil
+			return negateNumber(right)
+		case Tilde:
+			switch number := right.(type) {
+			case int64:
+				return nil, ^number
+			case *big.Int:
+				return nil, new(big.Int).Not(number)
 			}
-			return nil, -rhs
+			return NewRuntimeError("Bitwise operand must be an integer"), nil
 		case Bang:
 			return nil, i.isTruthy(right)
 		default:
-			return u.NewError("Unexpected unary operator '%v'", operator), nil
+			return NewRuntimeError("Unexpected unary operator '%v'", operator), nil
 		}
+	case *InterpolatedString:
+		var result strings.Builder
+		for _, part := range option.parts {
+			err, value := i.evaluate(part)
+			if err != nil {
+				return err, nil
+			}
+			result.WriteString(stringify(value))
+		}
+		return nil, result.String()
 	case *Grouping:
 		return i.evaluate(option.expression)
 	case *Literal:
@@ -433,3 +1141,250 @@ func (i Interpreter) evaluate(expression Expression) (error, any) {
 		return u.NewError("Unreachable evaluate"), nil
 	}
 }
+
+func (i Interpreter) performBinaryOperation(operator *Token, left any, right any) (error, any) {
+	switch operator := operator.tokenType; operator {
+	case Greater:
+		return compareNumbers(left, right, func(order int) bool {
+			return order > 0
+		})
+	case GreaterEqual:
+		return compareNumbers(left, right, func(order int) bool {
+			return order >= 0
+		})
+	case Less:
+		return compareNumbers(left, right, func(order int) bool {
+			return order < 0
+		})
+	case LessEqual:
+		return compareNumbers(left, right, func(order int) bool {
+			return order <= 0
+		})
+	case BangEqual:
+		return nil, !i.isEqual(left, right)
+	case EqualEqual:
+		return nil, i.isEqual(left, right)
+	case In:
+		return i.contains(right, left)
+	case Minus:
+		return performBinaryNumberOperation(left, right, numberOperation{
+			integer: func(lhs int64, rhs int64) (error, any) {
+				return nil, subtractIntegers(lhs, rhs)
+			},
+			bigInt: func(lhs *big.Int, rhs *big.Int) (error, any) {
+				return nil, new(big.Int).Sub(lhs, rhs)
+			},
+			decimal: func(lhs *GSDecimal, rhs *GSDecimal) (error, any) {
+				return nil, lhs.sub(rhs)
+			},
+			float: func(lhs float64, rhs float64) (error, any) {
+				return nil, lhs - rhs
+			},
+		})
+	case Star:
+		return performBinaryNumberOperation(left, right, numberOperation{
+			integer: func(lhs int64, rhs int64) (error, any) {
+				return nil, multiplyIntegers(lhs, rhs)
+			},
+			bigInt: func(lhs *big.Int, rhs *big.Int) (error, any) {
+				return nil, new(big.Int).Mul(lhs, rhs)
+			},
+			decimal: func(lhs *GSDecimal, rhs *GSDecimal) (error, any) {
+				return nil, lhs.mul(rhs)
+			},
+			float: func(lhs float64, rhs float64) (error, any) {
+				return nil, lhs * rhs
+			},
+		})
+	case Slash:
+		return performBinaryNumberOperation(left, right, numberOperation{
+			integer: func(lhs int64, rhs int64) (error, any) {
+				if rhs == 0 {
+					return NewDivisionByZeroError(), nil
+				}
+				return nil, divideIntegers(lhs, rhs)
+			},
+			bigInt: func(lhs *big.Int, rhs *big.Int) (error, any) {
+				if rhs.Sign() == 0 {
+					return NewDivisionByZeroError(), nil
+				}
+				return nil, new(big.Int).Quo(lhs, rhs)
+			},
+			decimal: func(lhs *GSDecimal, rhs *GSDecimal) (error, any) {
+				return lhs.div(rhs, i.decimalContext)
+			},
+			float: func(lhs float64, rhs float64) (error, any) {
+				if rhs == 0 {
+					return NewDivisionByZeroError(), nil
+				}
+				return nil, lhs / rhs
+			},
+		})
+	case Percent:
+		return performBinaryNumberOperation(left, right, numberOperation{
+			integer: func(lhs int64, rhs int64) (error, any) {
+				if rhs == 0 {
+					return NewDivisionByZeroError(), nil
+				}
+				if rhs == -1 {
+					return nil, int64(0)
+				}
+				return nil, lhs % rhs
+			},
+			bigInt: func(lhs *big.Int, rhs *big.Int) (error, any) {
+				if rhs.Sign() == 0 {
+					return NewDivisionByZeroError(), nil
+				}
+				return nil, new(big.Int).Rem(lhs, rhs)
+			},
+			decimal: func(lhs *GSDecimal, rhs *GSDecimal) (error, any) {
+				err, _, remainder := lhs.quo(rhs, RoundDown)
+				return err, remainder
+			},
+			float: func(lhs float64, rhs float64) (error, any) {
+				if rhs == 0 {
+					return NewDivisionByZeroError(), nil
+				}
+				return nil, math.Mod(lhs, rhs)
+			},
+		})
+	case TildeSlash:
+		return performBinaryNumberOperation(left, right, numberOperation{
+			integer: func(lhs int64, rhs int64) (error, any) {
+				if rhs == 0 {
+					return NewDivisionByZeroError(), nil
+				}
+				return nil, floorDivide(lhs, rhs)
+			},
+			bigInt: func(lhs *big.Int, rhs *big.Int) (error, any) {
+				if rhs.Sign() == 0 {
+					return NewDivisionByZeroError(), nil
+				}
+				return nil, floorDivideBig(lhs, rhs)
+			},
+			decimal: func(lhs *GSDecimal, rhs *GSDecimal) (error, any) {
+				err, quotient, _ := lhs.quo(rhs, RoundFloor)
+				return err, quotient
+			},
+			float: func(lhs float64, rhs float64) (error, any) {
+				if rhs == 0 {
+					return NewDivisionByZeroError(), nil
+				}
+				return nil, math.Floor(lhs / rhs)
+			},
+		})
+	case StarStar:
+		return performBinaryNumberOperation(left, right, numberOperation{
+			integer: func(lhs int64, rhs int64) (error, any) {
+				if rhs < 0 {
+					return nil, math.Pow(float64(lhs), float64(rhs))
+				}
+				if err := checkPower(big.NewInt(lhs), big.NewInt(rhs)); err != nil {
+					return err, nil
+				}
+				return nil, promote(new(big.Int).Exp(big.NewInt(lhs), big.NewInt(rhs), nil))
+			},
+			bigInt: func(lhs *big.Int, rhs *big.Int) (error, any) {
+				if rhs.Sign() < 0 {
+					_, base := toFloat(lhs)
+					_, exponent := toFloat(rhs)
+					return nil, math.Pow(base, exponent)
+				}
+				if err := checkPower(lhs, rhs); err != nil {
+					return err, nil
+				}
+				return nil, new(big.Int).Exp(lhs, rhs, nil)
+			},
+			decimal: func(lhs *GSDecimal, rhs *GSDecimal) (error, any) {
+				exponent := rhs.trim(0)
+				if exponent.scale != 0 || exponent.unscaled.Sign() < 0 || !exponent.unscaled.IsInt64() {
+					return NewRuntimeError("Decimal exponent must be a non-negative integer"), nil
+				}
+				power := exponent.unscaled.Int64()
+				if err := checkPower(lhs.unscaled, exponent.unscaled); err != nil {
+					return err, nil
+				}
+				if lhs.scale > 0 && power > MAX_DECIMAL_SCALE/int64(lhs.scale) {
+					return NewRuntimeError("Decimal scale exceeds %v digits", MAX_DECIMAL_SCALE), nil
+				}
+				return nil, lhs.pow(power)
+			},
+			float: func(lhs float64, rhs float64) (error, any) {
+				return nil, math.Pow(lhs, rhs)
+			},
+		})
+	case Ampersand:
+		return performBinaryIntegerOperation(left, right, func(lhs int64, rhs int64) (error, any) {
+			return nil, lhs & rhs
+		}, func(lhs *big.Int, rhs *big.Int) (error, any) {
+			return nil, new(big.Int).And(lhs, rhs)
+		})
+	case Pipe:
+		return performBinaryIntegerOperation(left, right, func(lhs int64, rhs int64) (error, any) {
+			return nil, lhs | rhs
+		}, func(lhs *big.Int, rhs *big.Int) (error, any) {
+			return nil, new(big.Int).Or(lhs, rhs)
+		})
+	case Caret:
+		return performBinaryIntegerOperation(left, right, func(lhs int64, rhs int64) (error, any) {
+			return nil, lhs ^ rhs
+		}, func(lhs *big.Int, rhs *big.Int) (error, any) {
+			return nil, new(big.Int).Xor(lhs, rhs)
+		})
+	case LessLess:
+		return performBinaryIntegerOperation(left, right, func(lhs int64, rhs int64) (error, any) {
+			if rhs < 0 {
+				return NewRuntimeError("Negative shift count %v", rhs), nil
+			}
+			if err := checkShift(big.NewInt(lhs), rhs); err != nil {
+				return err, nil
+			}
+			return nil, promote(new(big.Int).Lsh(big.NewInt(lhs), uint(rhs)))
+		}, func(lhs *big.Int, rhs *big.Int) (error, any) {
+			if rhs.Sign() < 0 || !rhs.IsInt64() {
+				return NewRuntimeError("Invalid shift count %v", rhs), nil
+			}
+			if err := checkShift(lhs, rhs.Int64()); err != nil {
+				return err, nil
+			}
+			return nil, new(big.Int).Lsh(lhs, uint(rhs.Int64()))
+		})
+	case GreaterGreater:
+		return performBinaryIntegerOperation(left, right, func(lhs int64, rhs int64) (error, any) {
+			if rhs < 0 {
+				return NewRuntimeError("Negative shift count %v", rhs), nil
+			}
+			return nil, lhs >> rhs
+		}, func(lhs *big.Int, rhs *big.Int) (error, any) {
+			if rhs.Sign() < 0 || !rhs.IsInt64() {
+				return NewRuntimeError("Invalid shift count %v", rhs), nil
+			}
+			return nil, new(big.Int).Rsh(lhs, uint(rhs.Int64()))
+		})
+	case Plus:
+		if u.IsString(left) && u.IsString(right) {
+			lhs := u.AsString(left)
+			rhs := u.AsString(right)
+			return nil, lhs + rhs
+		}
+		if isNumber(left) && isNumber(right) {
+			return performBinaryNumberOperation(left, right, numberOperation{
+				integer: func(lhs int64, rhs int64) (error, any) {
+					return nil, addIntegers(lhs, rhs)
+				},
+				bigInt: func(lhs *big.Int, rhs *big.Int) (error, any) {
+					return nil, new(big.Int).Add(lhs, rhs)
+				},
+				decimal: func(lhs *GSDecimal, rhs *GSDecimal) (error, any) {
+					return nil, lhs.add(rhs)
+				},
+				float: func(lhs float64, rhs float64) (error, any) {
+					return nil, lhs + rhs
+				},
+			})
+		}
+		return NewRuntimeError("Unexpected plus types T1:'%T' T2:'%T'", left, right), nil
+	default:
+		return NewRuntimeError("Unexpected binary operator '%v'", operator), nil
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+const (
+	IterMethod = "iter"
+	NextMethod = "next"
+)
+
+// GSIterator produces the values a for-in loop walks over. It returns false
+// once there are no values left.
+type GSIterator func() (error, any, bool)
+
+// iterator returns a GSIterator over arrays, map keys, the characters of a
+// string, ranges, or a struct instance implementing iter() and next(). next()
+// returns null when the iteration is over.
+func (i Interpreter) iterator(iterable any) (error, GSIterator) {
+	switch option := iterable.(type) {
+	case *GSArray:
+		return nil, sliceIterator(option.elements)
+	case *GSMap:
+		keys := append([]any{}, option.keys...)
+		return nil, sliceIterator(keys)
+	case *GSRange:
+		return nil, option.iterator()
+	case string:
+		return nil, sliceIterator(characters(option))
+	case *GSInstance:
+		object := option
+		if iter := option.gsStruct.findMethod(IterMethod); iter != nil {
+			err, value := iter.bind(option).call(&i, []any{})
+			if err != nil {
+				return err, nil
+			}
+			instance, ok := value.(*GSInstance)
+			if !ok {
+				return NewRuntimeError("'%v' must return an iterator", IterMethod), nil
+			}
+			object = instance
+		}
+		next := object.gsStruct.findMethod(NextMethod)
+		if next == nil {
+			return NewRuntimeError("'%v' is not iterable", stringify(iterable)), nil
+		}
+		bound := next.bind(object)
+		return nil, func() (error, any, bool) {
+			err, value := bound.call(&i, []any{})
+			if err != nil {
+				return err, nil, false
+			}
+			return nil, value, value != nil
+		}
+	}
+	return NewRuntimeError("'%v' is not iterable", stringify(iterable)), nil
+}
+
+func sliceIterator(values []any) GSIterator {
+	index := 0
+	return func() (error, any, bool) {
+		if index >= len(values) {
+			return nil, nil, false
+		}
+		index++
+		return nil, values[index-1], true
+	}
+}
+
+func characters(text string) []any {
+	result := []any{}
+	for _, character := range text {
+		result = append(result, string(character))
+	}
+	return result
+}
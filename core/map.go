@@ -0,0 +1,112 @@
+package main
+
+import (
+	"math/big"
+)
+
+type GSMap struct {
+	keys   []any
+	values map[any]any
+}
+
+func NewGSMap() *GSMap {
+	keys := []any{}
+	values := map[any]any{}
+	return &GSMap{
+		keys,
+		values,
+	}
+}
+
+func (m GSMap) String() string {
+	entries := NewIterator[string]()
+	for _, key := range m.keys {
+		entries.Push(stringify(key) + ": " + stringify(m.values[hashKey(key)]))
+	}
+	return "{" + entries.Join(", ") + "}"
+}
+
+// numericKey is how numbers other than int64 are stored in the values map,
+// since pointers to equal numbers don't compare equal. It holds the exact
+// rational value of the number, so equal numbers of any kind share an entry.
+type numericKey string
+
+// normalizeKey validates a key and stores integral numbers as integers, so that
+// m[1], m[1.0] and m[1.00m] refer to the same entry.
+func (m GSMap) normalizeKey(key any) (error, any) {
+	switch key.(type) {
+	case string, int64:
+		return nil, key
+	case *big.Int, *GSDecimal, float64:
+		_, rat := toRat(key)
+		if rat != nil && rat.IsInt() && rat.Num().IsInt64() {
+			return nil, rat.Num().Int64()
+		}
+		if decimal, ok := key.(*GSDecimal); ok {
+			return nil, decimal.trim(0)
+		}
+		return nil, key
+	}
+	return NewRuntimeError("Map key must be a string or a number, got '%T'", key), nil
+}
+
+// hashKey returns the values map key of a normalized key. Infinities and NaN
+// have no rational value and are stored as themselves.
+func hashKey(key any) any {
+	switch key.(type) {
+	case *big.Int, *GSDecimal, float64:
+		if _, rat := toRat(key); rat != nil {
+			return numericKey(rat.RatString())
+		}
+	}
+	return key
+}
+
+func (m GSMap) has(key any) (error, bool) {
+	err, key := m.normalizeKey(key)
+	if err != nil {
+		return err, false
+	}
+	_, ok := m.values[hashKey(key)]
+	return nil, ok
+}
+
+func (m GSMap) get(key any) (error, any) {
+	err, key := m.normalizeKey(key)
+	if err != nil {
+		return err, nil
+	}
+	value, ok := m.values[hashKey(key)]
+	if !ok {
+		return NewRuntimeError("Undefined key '%v'", stringify(key)), nil
+	}
+	return nil, value
+}
+
+func (m *GSMap) set(key any, value any) error {
+	err, key := m.normalizeKey(key)
+	if err != nil {
+		return err
+	}
+	if _, ok := m.values[hashKey(key)]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[hashKey(key)] = value
+	return nil
+}
+
+func (m *GSMap) delete(key any) (error, bool) {
+	err, ok := m.has(key)
+	if err != nil || !ok {
+		return err, false
+	}
+	_, key = m.normalizeKey(key)
+	delete(m.values, hashKey(key))
+	for index, value := range m.keys {
+		if hashKey(value) == hashKey(key) {
+			m.keys = append(m.keys[:index], m.keys[index+1:]...)
+			break
+		}
+	}
+	return nil, true
+}
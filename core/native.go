@@ -2,21 +2,315 @@ package main
 
 import (
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 type Clock struct {
 }
 
-func (c Clock) arity() int {
+func (c Clock) minArity() int {
+	return 0
+}
+
+func (c Clock) maxArity() int {
 	return 0
 }
 
 func (c Clock) call(i *Interpreter, arguments []any) (error, any) {
 	fmt.Println("time.Now().UnixNano()", time.Now().UnixMilli())
-	return nil, float64(time.Now().UnixMilli())
+	return nil, time.Now().UnixMilli()
 }
 
 func (c Clock) String() string {
 	return "[fn: clock]"
 }
+
+type Len struct {
+}
+
+func (l Len) minArity() int {
+	return 1
+}
+
+func (l Len) maxArity() int {
+	return 1
+}
+
+func (l Len) call(i *Interpreter, arguments []any) (error, any) {
+	switch value := arguments[0].(type) {
+	case *GSArray:
+		return nil, int64(len(value.elements))
+	case *GSMap:
+		return nil, int64(len(value.keys))
+	case string:
+		return nil, int64(utf8.RuneCountInString(value))
+	case *GSRange:
+		return nil, value.length()
+	}
+	return NewRuntimeError("Can't get length of '%T'", arguments[0]), nil
+}
+
+func (l Len) String() string {
+	return "[fn: len]"
+}
+
+type Has struct {
+}
+
+func (h Has) minArity() int {
+	return 2
+}
+
+func (h Has) maxArity() int {
+	return 2
+}
+
+func (h Has) call(i *Interpreter, arguments []any) (error, any) {
+	if gsMap, ok := arguments[0].(*GSMap); ok {
+		return gsMap.has(arguments[1])
+	}
+	return NewRuntimeError("Expected map as first argument of has, got '%T'", arguments[0]), nil
+}
+
+func (h Has) String() string {
+	return "[fn: has]"
+}
+
+type Delete struct {
+}
+
+func (d Delete) minArity() int {
+	return 2
+}
+
+func (d Delete) maxArity() int {
+	return 2
+}
+
+func (d Delete) call(i *Interpreter, arguments []any) (error, any) {
+	if gsMap, ok := arguments[0].(*GSMap); ok {
+		return gsMap.delete(arguments[1])
+	}
+	return NewRuntimeError("Expected map as first argument of delete, got '%T'", arguments[0]), nil
+}
+
+func (d Delete) String() string {
+	return "[fn: delete]"
+}
+
+type Keys struct {
+}
+
+func (k Keys) minArity() int {
+	return 1
+}
+
+func (k Keys) maxArity() int {
+	return 1
+}
+
+func (k Keys) call(i *Interpreter, arguments []any) (error, any) {
+	if gsMap, ok := arguments[0].(*GSMap); ok {
+		keys := make([]any, len(gsMap.keys))
+		copy(keys, gsMap.keys)
+		return nil, NewGSArray(keys)
+	}
+	return NewRuntimeError("Expected map as argument of keys, got '%T'", arguments[0]), nil
+}
+
+func (k Keys) String() string {
+	return "[fn: keys]"
+}
+
+type ToDecimal struct {
+}
+
+func (d ToDecimal) minArity() int {
+	return 1
+}
+
+func (d ToDecimal) maxArity() int {
+	return 1
+}
+
+func (d ToDecimal) call(i *Interpreter, arguments []any) (error, any) {
+	switch value := arguments[0].(type) {
+	case *GSDecimal:
+		return nil, value
+	case int64, *big.Int:
+		return nil, toDecimal(value)
+	case float64:
+		return ParseGSDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+	case string:
+		return ParseGSDecimal(strings.TrimSpace(value))
+	}
+	return NewRuntimeError("Can't convert '%T' to decimal", arguments[0]), nil
+}
+
+func (d ToDecimal) String() string {
+	return "[fn: decimal]"
+}
+
+type Round struct {
+}
+
+func (r Round) minArity() int {
+	return 2
+}
+
+func (r Round) maxArity() int {
+	return 2
+}
+
+func (r Round) call(i *Interpreter, arguments []any) (error, any) {
+	places, ok := arguments[1].(int64)
+	if !ok {
+		return NewRuntimeError("Expected integer number of places, got '%T'", arguments[1]), nil
+	}
+	if err := checkScale(places); err != nil {
+		return err, nil
+	}
+	switch value := arguments[0].(type) {
+	case *GSDecimal:
+		return value.rescale(int32(places), i.decimalContext.rounding)
+	case int64, *big.Int:
+		if places >= 0 {
+			return nil, value
+		}
+		roundErr, rounded := toDecimal(value).rescale(int32(places), i.decimalContext.rounding)
+		if roundErr != nil {
+			return roundErr, nil
+		}
+		return nil, promote(new(big.Int).Mul(rounded.unscaled, powerOfTen(-rounded.scale)))
+	case float64:
+		err, decimal := ParseGSDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+		if err != nil {
+			return err, nil
+		}
+		roundErr, rounded := decimal.rescale(int32(places), i.decimalContext.rounding)
+		if roundErr != nil {
+			return roundErr, nil
+		}
+		return toFloat(rounded)
+	}
+	return NewRuntimeError("Can't round '%T'", arguments[0]), nil
+}
+
+func (r Round) String() string {
+	return "[fn: round]"
+}
+
+type SetDecimalContext struct {
+}
+
+func (d SetDecimalContext) minArity() int {
+	return 2
+}
+
+func (d SetDecimalContext) maxArity() int {
+	return 2
+}
+
+func (d SetDecimalContext) call(i *Interpreter, arguments []any) (error, any) {
+	scale, ok := arguments[0].(int64)
+	if !ok || scale < 0 {
+		return NewRuntimeError("Decimal scale must be a non-negative integer"), nil
+	}
+	if err := checkScale(scale); err != nil {
+		return err, nil
+	}
+	rounding, ok := arguments[1].(string)
+	if !ok || !roundingModes[rounding] {
+		return NewRuntimeError("Unknown rounding mode '%v'", stringify(arguments[1])), nil
+	}
+	i.decimalContext.scale = int32(scale)
+	i.decimalContext.rounding = rounding
+	return nil, nil
+}
+
+func (d SetDecimalContext) String() string {
+	return "[fn: decimalContext]"
+}
+
+type NewError struct {
+}
+
+func (e NewError) minArity() int {
+	return 1
+}
+
+func (e NewError) maxArity() int {
+	return 2
+}
+
+func (e NewError) call(i *Interpreter, arguments []any) (error, any) {
+	message, ok := arguments[0].(string)
+	if !ok {
+		return NewRuntimeError("Error message must be a string, got %v", stringify(arguments[0])), nil
+	}
+	kind := ErrorKindError
+	if len(arguments) > 1 {
+		name, ok := arguments[1].(string)
+		if !ok || name == "" {
+			return NewRuntimeError("Error kind must be a non-empty string, got %v", stringify(arguments[1])), nil
+		}
+		kind = name
+	}
+	return nil, NewGSError(message, kind, 0, nil)
+}
+
+func (e NewError) String() string {
+	return "[fn: error]"
+}
+
+type Doc struct {
+}
+
+func (d Doc) minArity() int {
+	return 1
+}
+
+func (d Doc) maxArity() int {
+	return 2
+}
+
+// call returns the doc comment of a function, or of a struct member given its
+// name, and null when there is none.
+func (d Doc) call(i *Interpreter, arguments []any) (error, any) {
+	var function *GSFunction
+	switch value := arguments[0].(type) {
+	case *GSFunction:
+		function = value
+	case *GSStruct:
+		if len(arguments) < 2 {
+			return NewRuntimeError("Expected member name as second argument of doc"), nil
+		}
+		name, ok := arguments[1].(string)
+		if !ok {
+			return NewRuntimeError("Expected member name as second argument of doc, got '%T'", arguments[1]), nil
+		}
+		for _, member := range []*GSFunction{value.findMethod(name), value.findStaticMethod(name), value.findGetter(name), value.findSetter(name)} {
+			if member != nil && len(member.declaration.name.trivia) > 0 {
+				function = member
+				break
+			}
+		}
+		if function == nil {
+			return nil, nil
+		}
+	default:
+		return NewRuntimeError("Expected function or struct as argument of doc, got '%T'", arguments[0]), nil
+	}
+	if len(function.declaration.name.trivia) == 0 {
+		return nil, nil
+	}
+	return nil, strings.Join(function.declaration.name.trivia, "\n")
+}
+
+func (d Doc) String() string {
+	return "[fn: doc]"
+}
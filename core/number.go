@@ -0,0 +1,295 @@
+package main
+
+import (
+	"cmp"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	u "github.com/core/utils"
+)
+
+const (
+	NumberInteger = "integer"
+	NumberBigInt  = "bigInt"
+	NumberDecimal = "decimal"
+	NumberFloat   = "float"
+)
+
+// Numbers are int64, *big.Int, *GSDecimal or float64. Two integers stay
+// integers and silently grow into a big integer on overflow. Integers combined
+// with a decimal become decimals. As soon as a float takes part the operation
+// is performed on floats, except with decimals, where mixing is an error
+// because it would lose the exactness decimals exist for.
+type numberOperation struct {
+	integer func(lhs int64, rhs int64) (error, any)
+	bigInt  func(lhs *big.Int, rhs *big.Int) (error, any)
+	decimal func(lhs *GSDecimal, rhs *GSDecimal) (error, any)
+	float   func(lhs float64, rhs float64) (error, any)
+}
+
+func numberKind(value any) string {
+	switch value.(type) {
+	case int64:
+		return NumberInteger
+	case *big.Int:
+		return NumberBigInt
+	case *GSDecimal:
+		return NumberDecimal
+	}
+	return NumberFloat
+}
+
+func isNumber(value any) bool {
+	switch value.(type) {
+	case int64, float64, *big.Int, *GSDecimal:
+		return true
+	}
+	return false
+}
+
+func toBigInt(value any) *big.Int {
+	if integer, ok := value.(int64); ok {
+		return big.NewInt(integer)
+	}
+	return value.(*big.Int)
+}
+
+func toDecimal(value any) *GSDecimal {
+	if decimal, ok := value.(*GSDecimal); ok {
+		return decimal
+	}
+	return DecimalFromInteger(toBigInt(value))
+}
+
+func toFloat(value any) (error, float64) {
+	switch option := value.(type) {
+	case *big.Int:
+		float, _ := new(big.Float).SetInt(option).Float64()
+		return nil, float
+	case *GSDecimal:
+		float, _ := option.rat().Float64()
+		return nil, float
+	}
+	return u.AsFloat(value)
+}
+
+// toRat converts a number to an exact rational. Infinities and NaN have no
+// rational value and come back as nil.
+func toRat(value any) (error, *big.Rat) {
+	switch option := value.(type) {
+	case int64:
+		return nil, new(big.Rat).SetInt64(option)
+	case *big.Int:
+		return nil, new(big.Rat).SetInt(option)
+	case *GSDecimal:
+		return nil, option.rat()
+	}
+	err, float := u.AsFloat(value)
+	if err != nil {
+		return err, nil
+	}
+	if math.IsInf(float, 0) || math.IsNaN(float) {
+		return nil, nil
+	}
+	return nil, new(big.Rat).SetFloat64(float)
+}
+
+// MAX_INTEGER_BITS is the widest integer a shift or power may build, so a single
+// expression can't hang the interpreter or exhaust memory.
+const MAX_INTEGER_BITS = 1 << 20
+
+// checkShift rejects shifting value left by count when the result would be too wide.
+func checkShift(value *big.Int, count int64) error {
+	if value.Sign() != 0 && count > MAX_INTEGER_BITS-int64(value.BitLen()) {
+		return NewRuntimeError("Shift result exceeds %v bits", MAX_INTEGER_BITS)
+	}
+	return nil
+}
+
+// checkPower rejects base ** exponent when the result would be too wide.
+func checkPower(base *big.Int, exponent *big.Int) error {
+	bits := int64(base.BitLen() - 1)
+	if bits > 0 && (!exponent.IsInt64() || exponent.Int64() > MAX_INTEGER_BITS/bits) {
+		return NewRuntimeError("Power result exceeds %v bits", MAX_INTEGER_BITS)
+	}
+	return nil
+}
+
+// promote returns the result as an int64 when it fits, as a big integer otherwise.
+func promote(value *big.Int) any {
+	if value.IsInt64() {
+		return value.Int64()
+	}
+	return value
+}
+
+func performBinaryNumberOperation(left any, right any, operation numberOperation) (error, any) {
+	leftKind := numberKind(left)
+	rightKind := numberKind(right)
+	if leftKind == NumberInteger && rightKind == NumberInteger {
+		return operation.integer(left.(int64), right.(int64))
+	}
+	if leftKind == NumberFloat || rightKind == NumberFloat {
+		if leftKind == NumberDecimal || rightKind == NumberDecimal {
+			return NewRuntimeError("Can't mix decimal and float operands, convert with decimal()"), nil
+		}
+		leftErr, lhs := toFloat(left)
+		if leftErr != nil {
+			return leftErr, nil
+		}
+		rightErr, rhs := toFloat(right)
+		if rightErr != nil {
+			return rightErr, nil
+		}
+		return operation.float(lhs, rhs)
+	}
+	if leftKind == NumberDecimal || rightKind == NumberDecimal {
+		return operation.decimal(toDecimal(left), toDecimal(right))
+	}
+	return operation.bigInt(toBigInt(left), toBigInt(right))
+}
+
+func performBinaryIntegerOperation(left any,
+	right any,
+	integerOperation func(lhs int64, rhs int64) (error, any),
+	bigIntOperation func(lhs *big.Int, rhs *big.Int) (error, any)) (error, any) {
+	leftKind := numberKind(left)
+	rightKind := numberKind(right)
+	if (leftKind != NumberInteger && leftKind != NumberBigInt) || (rightKind != NumberInteger && rightKind != NumberBigInt) {
+		return NewRuntimeError("Bitwise operands must be integers"), nil
+	}
+	if leftKind == NumberInteger && rightKind == NumberInteger {
+		return integerOperation(left.(int64), right.(int64))
+	}
+	return bigIntOperation(toBigInt(left), toBigInt(right))
+}
+
+func compareNumbers(left any, right any, check func(order int) bool) (error, any) {
+	lhsInteger, leftIsInteger := left.(int64)
+	rhsInteger, rightIsInteger := right.(int64)
+	if leftIsInteger && rightIsInteger {
+		return nil, check(cmp.Compare(lhsInteger, rhsInteger))
+	}
+	leftErr, lhs := toRat(left)
+	if leftErr != nil {
+		return leftErr, nil
+	}
+	rightErr, rhs := toRat(right)
+	if rightErr != nil {
+		return rightErr, nil
+	}
+	if lhs != nil && rhs != nil {
+		return nil, check(lhs.Cmp(rhs))
+	}
+	_, lhsFloat := toFloat(left)
+	_, rhsFloat := toFloat(right)
+	if math.IsNaN(lhsFloat) || math.IsNaN(rhsFloat) {
+		return nil, false
+	}
+	return nil, check(cmp.Compare(lhsFloat, rhsFloat))
+}
+
+func numbersEqual(left any, right any) bool {
+	_, equal := compareNumbers(left, right, func(order int) bool {
+		return order == 0
+	})
+	return equal == true
+}
+
+func NewDivisionByZeroError() error {
+	return NewRuntimeError("Division by zero")
+}
+
+func addIntegers(lhs int64, rhs int64) any {
+	sum := lhs + rhs
+	if (sum > lhs) == (rhs > 0) {
+		return sum
+	}
+	return new(big.Int).Add(big.NewInt(lhs), big.NewInt(rhs))
+}
+
+func subtractIntegers(lhs int64, rhs int64) any {
+	difference := lhs - rhs
+	if (difference < lhs) == (rhs > 0) {
+		return difference
+	}
+	return new(big.Int).Sub(big.NewInt(lhs), big.NewInt(rhs))
+}
+
+func multiplyIntegers(lhs int64, rhs int64) any {
+	if lhs == 0 || rhs == 0 {
+		return int64(0)
+	}
+	product := lhs * rhs
+	overflow := product/rhs != lhs ||
+		(lhs == -1 && rhs == math.MinInt64) ||
+		(rhs == -1 && lhs == math.MinInt64)
+	if !overflow {
+		return product
+	}
+	return new(big.Int).Mul(big.NewInt(lhs), big.NewInt(rhs))
+}
+
+func divideIntegers(lhs int64, rhs int64) any {
+	if lhs == math.MinInt64 && rhs == -1 {
+		return new(big.Int).Neg(big.NewInt(lhs))
+	}
+	return lhs / rhs
+}
+
+func negateInteger(value int64) any {
+	if value == math.MinInt64 {
+		return new(big.Int).Neg(big.NewInt(value))
+	}
+	return -value
+}
+
+func negateNumber(value any) (error, any) {
+	switch number := value.(type) {
+	case int64:
+		return nil, negateInteger(number)
+	case *big.Int:
+		return nil, new(big.Int).Neg(number)
+	case *GSDecimal:
+		return nil, number.neg()
+	}
+	err, float := u.AsFloat(value)
+	if err != nil {
+		return err, nil
+	}
+	return nil, -float
+}
+
+func floorDivide(lhs int64, rhs int64) any {
+	if lhs == math.MinInt64 && rhs == -1 {
+		return divideIntegers(lhs, rhs)
+	}
+	quotient := lhs / rhs
+	if lhs%rhs != 0 && (lhs < 0) != (rhs < 0) {
+		quotient--
+	}
+	return quotient
+}
+
+func floorDivideBig(lhs *big.Int, rhs *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(lhs, rhs, new(big.Int))
+	if remainder.Sign() != 0 && remainder.Sign() != rhs.Sign() {
+		quotient.Sub(quotient, big.NewInt(1))
+	}
+	return quotient
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+	magnitude := math.Abs(value)
+	format := u.Ternary(magnitude == 0 || (magnitude >= 1e-6 && magnitude < 1e21), byte('f'), byte('g'))
+	text := strconv.FormatFloat(value, format, -1, 64)
+	if !strings.ContainsAny(text, ".e") {
+		text += ".0"
+	}
+	return text
+}
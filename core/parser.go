@@ -1,48 +1,94 @@
 // expression → assignment ;
-// assignment → ( call "." )? IDENTIFIER "=" assignment | ternary ;
+// assignment → ( call "." )? IDENTIFIER "=" assignment | call "[" expression "]" "=" assignment
+//   | arrayTarget "=" assignment
+//   | target ( "+=" | "-=" | "*=" | "/=" ) assignment | ternary ;
+// target → ( call "." )? IDENTIFIER | call "[" expression "]" ;
+// arrayTarget → "[" ( ( target | arrayTarget ) ( "," ( target | arrayTarget ) )* ( "," "..." target )? | "..." target )? "]" ;
 // ternary → logicOr ( ? ternary : ternary ) ;
 // logicOr → logicAnd ( || logicAnd )*;
 // logicAnd → equality ( && equality  )*;
 // equality → comparison ( ( "!=" | "==" ) comparison )* ;
-// comparison → term ( ( ">" | ">=" | "<" | "<=" ) term )* ;
+// comparison → range ( ( ">" | ">=" | "<" | "<=" | "in" ) range )* ;
+// range → bitOr ( ( ".." | "..=" ) bitOr ( "step" bitOr )? )? ;
+// bitOr → bitXor ( "|" bitXor )* ;
+// bitXor → bitAnd ( "^" bitAnd )* ;
+// bitAnd → shift ( "&" shift )* ;
+// shift → term ( ( "<<" | ">>" ) term )* ;
 // term → factor ( ( "-" | "+" ) factor )* ;
-// factor → unary ( ( "/" | "*" ) unary )* ;
-// unary → ( "!" | "-" ) unary | function ;
+// factor → unary ( ( "/" | "*" | "%" | "~/" ) unary )* ;
+//   integer division is "~/" rather than "//", which already starts a line comment.
+// unary → ( "!" | "-" | "~" ) unary | ( "++" | "--" ) target | power ;
+// power → postfix ( "**" unary )? ;
+// postfix → function ( "++" | "--" )? ;
 // function → "fn" IDENTIFIER ? "(" parameters? ")" block ;
-// parameters → IDENTIFIER ( "," IDENTIFIER )* ;
-// call → primary ( "(" arguments? ")" | "." IDENTIFIER )* ;
-// arguments → expression ( "," expression )* ;
-// primary → NUMBER | STRING | "true" | "false" | "nil" | "(" expression ")" | IDENTIFIER  ;
+// parameters → parameter ( "," parameter )* ( "," "..." IDENTIFIER )? | "..." IDENTIFIER ;
+// parameter → IDENTIFIER ( "=" expression )? ;
+// call → primary ( "(" arguments? ")" | "." IDENTIFIER | "[" expression "]" )* ;
+// arguments → argument ( "," argument )* ;
+// argument → ( IDENTIFIER ":" )? expression | "..." expression ;
+// primary → NUMBER | STRING | "true" | "false" | "nil" | "(" expression ")" | IDENTIFIER | "super" "." IDENTIFIER | "[" elements? "]" | "{" entries? "}"
+//   | ( INTERPOLATION expression )+ STRING | match | lambda ;
+// lambda → "(" parameters? ")" "=>" ( block | expression ) ;
+// elements → "..."? expression ( "," "..."? expression )* ;
+// entries → expression ":" expression ( "," expression ":" expression )* ;
+// match → "match" "(" expression ")" "{" arm ( "," arm )* ","? "}" ;
+// arm → pattern ( "if" expression )? "=>" expression ;
+// pattern → simplePattern ( "|" simplePattern )* ;
+// simplePattern → "_" | "-"? NUMBER | STRING | "true" | "false" | "null" | IDENTIFIER ( "{" fieldPatterns? "}" )? ;
+// fieldPatterns → IDENTIFIER ( ":" pattern )? ( "," IDENTIFIER ( ":" pattern )? )* ;
 
 // program → declaration* EOF ;
-// declaration → structDecl | fnDecl | letDecl | statement ;
-// statement → exprStmt | forStmt | ifStmt | printStmt | returnStmt | whileStmt | block ;
-// forStmt → "for" "(" ( letDecl | exprStmt | ";" ) expression? ";" expression? ")" statement ;
+// declaration → structDecl | fnDecl | letDecl | constDecl | statement ;
+// statement → exprStmt | forStmt | ifStmt | printStmt | returnStmt | whileStmt | breakStmt | continueStmt
+//   | labeledStmt | tryStmt | throwStmt | block ;
+// tryStmt → "try" block ( "catch" ( "(" IDENTIFIER ")" )? block )? ( "finally" block )? ;
+// throwStmt → "throw" expression ";" ;
+// labeledStmt → IDENTIFIER ":" ( forStmt | whileStmt ) ;
+// breakStmt → "break" IDENTIFIER? ";" ;
+// continueStmt → "continue" IDENTIFIER? ";" ;
+// forStmt → "for" "(" ( letDecl | exprStmt | ";" ) expression? ";" expression? ")" statement
+//   | "for" "(" IDENTIFIER "in" expression ")" statement ;
 // whileStmt → "while" "(" expression ")" statement ;
 // block → "{" declaration* "}"
 // ifStmt → "if" "(" expression ")" ( "else" "if" "(" expression ")" )* statement ( "else" statement )? ;
 // exprStmt → expression ";" ;
 // printStmt → "print" expression ";" ;
 // returnStmt → "return" expression? ";" ;
-// letDecl → "let" IDENTIFIER ( "=" expression )? ";" ;
-// structDecl → "struct" IDENTIFIER "{" function* "}" ;
+// letDecl → "let" bindingPattern ( "=" expression )? ";" ;
+// constDecl → "const" bindingPattern "=" expression ";" ;
+// bindingPattern → IDENTIFIER | "[" ( bindingPattern ( "," bindingPattern )* ( "," "..." IDENTIFIER )? | "..." IDENTIFIER )? "]"
+//   | "{" ( field ( "," field )* )? "}" ;
+// field → IDENTIFIER ( ":" bindingPattern )? ;
+// structDecl → "struct" IDENTIFIER ( "<" IDENTIFIER )? "{" member* "}" ;
+// member → "static"? "fn" function | "static" letDecl | getter | setter ;
+// getter → IDENTIFIER block ;
+// setter → "set" IDENTIFIER "(" IDENTIFIER ")" block ;
 // fnDecl → "fn" function ;
 // function → IDENTIFIER "(" parameters? ")" block ;
-// parameters → IDENTIFIER ( "," IDENTIFIER )* ;
 
 package main
 
 const MAX_FN_ARGUMENTS_COUNT = 255
 
+var compoundOperators = map[string]string{
+	PlusEqual:  Plus,
+	MinusEqual: Minus,
+	StarEqual:  Star,
+	SlashEqual: Slash,
+	PlusPlus:   Plus,
+	MinusMinus: Minus,
+}
+
 type Parser struct {
 	tokens       []*Token
 	current      int
 	currentBlock int
 	blockName    int
+	guardArrow   int
 }
 
 func NewParser(tokens []*Token) Parser {
-	return Parser{tokens, 0, 0, 0}
+	return Parser{tokens, 0, 0, 0, -1}
 }
 
 func (p *Parser) expression() (error, Expression) {
@@ -65,11 +111,76 @@ func (p *Parser) assignment() (error, Expression) {
 		if get, ok := (expression).(*Get); ok {
 			return nil, (NewSet(get.name, get.object, value))
 		}
+		if getIndex, ok := (expression).(*GetIndex); ok {
+			return nil, (NewSetIndex(getIndex.object, getIndex.bracket, getIndex.index, value))
+		}
+		if _, ok := (expression).(*ArrayLiteral); ok {
+			patternErr, pattern := p.targetPattern(expression)
+			if patternErr != nil {
+				return patternErr, nil
+			}
+			return nil, (NewDestructuringAssignment(pattern, value))
+		}
 		return NewParserError("Invalid assignment target"), nil
 	}
+	if p.match(PlusEqual, MinusEqual, StarEqual, SlashEqual) {
+		operator := p.compoundOperator()
+		err, value := p.assignment()
+		if err != nil {
+			return err, nil
+		}
+		if !p.isAssignmentTarget(expression) {
+			return NewParserError("Invalid assignment target"), nil
+		}
+		return nil, (NewCompoundAssignment(expression, operator, value))
+	}
 	return nil, expression
 }
 
+// targetPattern turns an array literal on the left of '=' into the pattern of
+// targets it assigns to.
+func (p Parser) targetPattern(expression Expression) (error, Pattern) {
+	if p.isAssignmentTarget(expression) {
+		return nil, NewTargetPattern(expression)
+	}
+	arrayLiteral, ok := expression.(*ArrayLiteral)
+	if !ok {
+		return NewParserError("Invalid destructuring target"), nil
+	}
+	elements := []Pattern{}
+	var rest Pattern
+	for index, element := range arrayLiteral.elements {
+		if spread, ok := element.(*Spread); ok {
+			if index != len(arrayLiteral.elements)-1 || !p.isAssignmentTarget(spread.expression) {
+				return NewParserError("Only the last destructuring target can collect the rest"), nil
+			}
+			rest = NewTargetPattern(spread.expression)
+			continue
+		}
+		err, pattern := p.targetPattern(element)
+		if err != nil {
+			return err, nil
+		}
+		elements = append(elements, pattern)
+	}
+	return nil, NewArrayPattern(elements, rest)
+}
+
+func (p Parser) isAssignmentTarget(expression Expression) bool {
+	switch (expression).(type) {
+	case *Variable, *Get, *GetIndex:
+		return true
+	}
+	return false
+}
+
+// compoundOperator turns the previous "+=", "++" and alike token into the binary
+// operator it applies.
+func (p Parser) compoundOperator() *Token {
+	token := p.previous()
+	return NewToken(compoundOperators[token.tokenType], token.lexeme, "", token.line, token.column)
+}
+
 func (p *Parser) ternary() (error, Expression) {
 	err, left := p.or()
 	if err != nil {
@@ -142,11 +253,100 @@ func (p *Parser) equality() (error, Expression) {
 }
 
 func (p *Parser) comparison() (error, Expression) {
+	err, expression := p.rangeExpression()
+	if err != nil {
+		return err, nil
+	}
+	for p.match(Greater, GreaterEqual, Less, LessEqual, In) {
+		operator := p.previous()
+		err, right := p.rangeExpression()
+		if err != nil {
+			return err, nil
+		}
+		expression = (NewBinary(expression, operator, right))
+	}
+	return nil, expression
+}
+
+func (p *Parser) rangeExpression() (error, Expression) {
+	err, start := p.bitOr()
+	if err != nil {
+		return err, nil
+	}
+	if !p.match(DotDot, DotDotEqual) {
+		return nil, start
+	}
+	operator := p.previous()
+	endErr, end := p.bitOr()
+	if endErr != nil {
+		return endErr, nil
+	}
+	var step Expression
+	if p.check(Identifier) && p.peek().lexeme == StepKeyword {
+		p.advance()
+		stepErr, expression := p.bitOr()
+		if stepErr != nil {
+			return stepErr, nil
+		}
+		step = expression
+	}
+	return nil, (NewRangeExpression(start, operator, end, step))
+}
+
+func (p *Parser) bitOr() (error, Expression) {
+	err, expression := p.bitXor()
+	if err != nil {
+		return err, nil
+	}
+	for p.match(Pipe) {
+		operator := p.previous()
+		err, right := p.bitXor()
+		if err != nil {
+			return err, nil
+		}
+		expression = (NewBinary(expression, operator, right))
+	}
+	return nil, expression
+}
+
+func (p *Parser) bitXor() (error, Expression) {
+	err, expression := p.bitAnd()
+	if err != nil {
+		return err, nil
+	}
+	for p.match(Caret) {
+		operator := p.previous()
+		err, right := p.bitAnd()
+		if err != nil {
+			return err, nil
+		}
+		expression = (NewBinary(expression, operator, right))
+	}
+	return nil, expression
+}
+
+func (p *Parser) bitAnd() (error, Expression) {
+	err, expression := p.shift()
+	if err != nil {
+		return err, nil
+	}
+	for p.match(Ampersand) {
+		operator := p.previous()
+		err, right := p.shift()
+		if err != nil {
+			return err, nil
+		}
+		expression = (NewBinary(expression, operator, right))
+	}
+	return nil, expression
+}
+
+func (p *Parser) shift() (error, Expression) {
 	err, expression := p.term()
 	if err != nil {
 		return err, nil
 	}
-	for p.match(Greater, GreaterEqual, Less, LessEqual) {
+	for p.match(LessLess, GreaterGreater) {
 		operator := p.previous()
 		err, right := p.term()
 		if err != nil {
@@ -178,7 +378,7 @@ func (p *Parser) factor() (error, Expression) {
 	if err != nil {
 		return err, nil
 	}
-	for p.match(Star, Slash) {
+	for p.match(Star, Slash, Percent, TildeSlash) {
 		operator := p.previous()
 		err, right := p.unary()
 		if err != nil {
@@ -190,7 +390,18 @@ func (p *Parser) factor() (error, Expression) {
 }
 
 func (p *Parser) unary() (error, Expression) {
-	if p.match(Minus, Plus) {
+	if p.match(PlusPlus, MinusMinus) {
+		operator := p.compoundOperator()
+		err, target := p.unary()
+		if err != nil {
+			return err, nil
+		}
+		if !p.isAssignmentTarget(target) {
+			return NewParserError("Invalid increment target"), nil
+		}
+		return nil, (NewUpdate(target, operator, true))
+	}
+	if p.match(Minus, Plus, Tilde) {
 		operator := p.previous()
 		err, right := p.unary()
 		if err != nil {
@@ -198,25 +409,70 @@ func (p *Parser) unary() (error, Expression) {
 		}
 		return nil, (NewUnary(operator, right))
 	}
-	err, call := p.function()
+	return p.power()
+}
+
+func (p *Parser) power() (error, Expression) {
+	err, expression := p.postfix()
+	if err != nil {
+		return err, nil
+	}
+	if p.match(StarStar) {
+		operator := p.previous()
+		err, right := p.unary()
+		if err != nil {
+			return err, nil
+		}
+		return nil, (NewBinary(expression, operator, right))
+	}
+	return nil, expression
+}
+
+func (p *Parser) postfix() (error, Expression) {
+	err, expression := p.function()
 	if err != nil {
 		return err, nil
 	}
-	return nil, call
+	if p.match(PlusPlus, MinusMinus) {
+		operator := p.compoundOperator()
+		if !p.isAssignmentTarget(expression) {
+			return NewParserError("Invalid increment target"), nil
+		}
+		return nil, (NewUpdate(expression, operator, false))
+	}
+	return nil, expression
 }
 
 func (p *Parser) finishCall(callee Expression) (error, Expression) {
 	arguments := []Expression{}
+	names := []*Token{}
+	isNamed := false
 	if !p.check(RightBrace) {
 		for {
 			if len(arguments) >= MAX_FN_ARGUMENTS_COUNT {
 				return NewParserError("Can't have more than %v arguments", MAX_FN_ARGUMENTS_COUNT), nil
 			}
+			var name *Token
+			if p.check(Identifier) && p.checkNext(Colon) {
+				name = p.advance()
+				p.advance()
+				isNamed = true
+			} else if isNamed {
+				return NewParserError("Positional arguments can't follow named arguments"), nil
+			}
+			var ellipsis *Token
+			if name == nil && p.match(DotDotDot) {
+				ellipsis = p.previous()
+			}
 			err, expression := p.expression()
 			if err != nil {
 				return err, nil
 			}
+			if ellipsis != nil {
+				expression = NewSpread(ellipsis, expression)
+			}
 			arguments = append(arguments, expression)
+			names = append(names, name)
 			if !p.match(Comma) {
 				break
 			}
@@ -226,7 +482,7 @@ func (p *Parser) finishCall(callee Expression) (error, Expression) {
 	if err != nil {
 		return err, nil
 	}
-	return nil, (NewCall(callee, paren, arguments))
+	return nil, (NewCall(callee, paren, arguments, names))
 }
 
 func (p *Parser) call() (error, Expression) {
@@ -247,6 +503,17 @@ func (p *Parser) call() (error, Expression) {
 				return err, nil
 			}
 			expression = NewGet(name, expression)
+		} else if p.match(LeftBracket) {
+			bracket := p.previous()
+			err, index := p.expression()
+			if err != nil {
+				return err, nil
+			}
+			consumeErr, _ := p.consume(RightBracket, "Expected ']' after index")
+			if consumeErr != nil {
+				return consumeErr, nil
+			}
+			expression = NewGetIndex(expression, bracket, index)
 		} else {
 			break
 		}
@@ -267,13 +534,31 @@ func (p *Parser) primary() (error, Expression) {
 	if p.match(Number, String) {
 		return nil, (NewLiteral(p.previous().literal))
 	}
+	if p.match(Interpolation) {
+		return p.interpolation()
+	}
 	if p.match(This) {
 		return nil, (NewThisExpression(p.previous()))
 	}
+	if p.match(Super) {
+		keyword := p.previous()
+		dotErr, _ := p.consume(Dot, "Expected '.' after 'super'")
+		if dotErr != nil {
+			return dotErr, nil
+		}
+		methodErr, method := p.consume(Identifier, "Expected superstruct method name")
+		if methodErr != nil {
+			return methodErr, nil
+		}
+		return nil, (NewSuperExpression(keyword, method))
+	}
 	if p.match(Identifier) {
 		return nil, (NewVariable(p.previous()))
 	}
 	if p.match(LeftBrace) {
+		if p.isLambda() {
+			return p.lambda()
+		}
 		expressionError, expression := p.expression()
 		if expressionError != nil {
 			return expressionError, nil
@@ -284,9 +569,306 @@ func (p *Parser) primary() (error, Expression) {
 		}
 		return nil, (NewGrouping(expression))
 	}
+	if p.match(LeftBracket) {
+		return p.arrayLiteral()
+	}
+	if p.match(LeftCurlyBracket) {
+		return p.mapLiteral()
+	}
+	if p.match(Match) {
+		return p.matchExpression()
+	}
 	return NewParserError("Unpredictable expression"), nil
 }
 
+func (p *Parser) matchExpression() (error, Expression) {
+	keyword := p.previous()
+	leftErr, _ := p.consume(LeftBrace, "Expected '(' after 'match'")
+	if leftErr != nil {
+		return leftErr, nil
+	}
+	subjectErr, subject := p.expression()
+	if subjectErr != nil {
+		return subjectErr, nil
+	}
+	rightErr, _ := p.consume(RightBrace, "Expected ')' after match value")
+	if rightErr != nil {
+		return rightErr, nil
+	}
+	openErr, _ := p.consume(LeftCurlyBracket, "Expected '{' before match arms")
+	if openErr != nil {
+		return openErr, nil
+	}
+	arms := []*MatchArm{}
+	for !p.check(RightCurlyBracket) && !p.isAtEnd() {
+		err, arm := p.matchArm()
+		if err != nil {
+			return err, nil
+		}
+		arms = append(arms, arm)
+		if !p.match(Comma) {
+			break
+		}
+	}
+	if len(arms) == 0 {
+		return NewParserError("Expected at least one match arm"), nil
+	}
+	closeErr, _ := p.consume(RightCurlyBracket, "Expected '}' after match arms")
+	if closeErr != nil {
+		return closeErr, nil
+	}
+	return nil, (NewMatchExpression(keyword, subject, arms))
+}
+
+func (p *Parser) matchArm() (error, *MatchArm) {
+	patternErr, pattern := p.pattern()
+	if patternErr != nil {
+		return patternErr, nil
+	}
+	var guard Expression
+	if p.match(If) {
+		enclosingArrow := p.guardArrow
+		p.guardArrow = p.guardEnd()
+		guardErr, expression := p.expression()
+		p.guardArrow = enclosingArrow
+		if guardErr != nil {
+			return guardErr, nil
+		}
+		guard = expression
+	}
+	arrowErr, _ := p.consume(FatArrow, "Expected '=>' after match pattern")
+	if arrowErr != nil {
+		return arrowErr, nil
+	}
+	bodyErr, body := p.expression()
+	if bodyErr != nil {
+		return bodyErr, nil
+	}
+	return nil, NewMatchArm(pattern, guard, body)
+}
+
+func (p *Parser) pattern() (error, Pattern) {
+	err, pattern := p.simplePattern()
+	if err != nil {
+		return err, nil
+	}
+	if !p.check(Pipe) {
+		return nil, pattern
+	}
+	alternatives := []Pattern{pattern}
+	for p.match(Pipe) {
+		alternativeErr, alternative := p.simplePattern()
+		if alternativeErr != nil {
+			return alternativeErr, nil
+		}
+		alternatives = append(alternatives, alternative)
+	}
+	return nil, NewAlternativePattern(alternatives)
+}
+
+func (p *Parser) simplePattern() (error, Pattern) {
+	if p.match(False) {
+		return nil, NewLiteralPattern(false)
+	}
+	if p.match(True) {
+		return nil, NewLiteralPattern(true)
+	}
+	if p.match(Null) {
+		return nil, NewLiteralPattern(nil)
+	}
+	if p.match(Number, String) {
+		return nil, NewLiteralPattern(p.previous().literal)
+	}
+	if p.match(Minus) {
+		numberErr, number := p.consume(Number, "Expected number after '-' in pattern")
+		if numberErr != nil {
+			return numberErr, nil
+		}
+		err, value := negateNumber(number.literal)
+		if err != nil {
+			return err, nil
+		}
+		return nil, NewLiteralPattern(value)
+	}
+	if p.match(Identifier) {
+		name := p.previous()
+		if name.lexeme == WildcardName {
+			return nil, NewWildcardPattern(name)
+		}
+		if p.match(LeftCurlyBracket) {
+			return p.structPattern(NewVariable(name))
+		}
+		return nil, NewBindingPattern(name)
+	}
+	return NewParserError("Expected pattern"), nil
+}
+
+func (p *Parser) structPattern(name *Variable) (error, Pattern) {
+	fields := []*Token{}
+	values := []Pattern{}
+	if !p.check(RightCurlyBracket) {
+		for {
+			fieldErr, field := p.consume(Identifier, "Expected field name in struct pattern")
+			if fieldErr != nil {
+				return fieldErr, nil
+			}
+			var value Pattern = NewBindingPattern(field)
+			if p.match(Colon) {
+				valueErr, pattern := p.pattern()
+				if valueErr != nil {
+					return valueErr, nil
+				}
+				value = pattern
+			}
+			fields = append(fields, field)
+			values = append(values, value)
+			if !p.match(Comma) {
+				break
+			}
+		}
+	}
+	consumeErr, _ := p.consume(RightCurlyBracket, "Expected '}' after struct pattern fields")
+	if consumeErr != nil {
+		return consumeErr, nil
+	}
+	return nil, NewStructPattern(name, fields, values)
+}
+
+// guardEnd returns the index of the '=>' ending the match guard that starts at
+// the current token, the first one outside any brackets.
+func (p Parser) guardEnd() int {
+	depth := 0
+	for index := p.current; index < len(p.tokens); index++ {
+		switch p.tokens[index].tokenType {
+		case LeftBrace, LeftBracket, LeftCurlyBracket:
+			depth++
+		case RightBrace, RightBracket, RightCurlyBracket:
+			depth--
+		case FatArrow:
+			if depth == 0 {
+				return index
+			}
+		case Eof:
+			return -1
+		}
+	}
+	return -1
+}
+
+// isLambda parses ahead, on a copy of the parser, a parameter list after the
+// parenthesis just consumed and checks that '=>' follows. The '=>' ending a
+// match guard never starts a lambda body, so a guard like n > (y) => ... is a
+// grouping.
+func (p Parser) isLambda() bool {
+	err, _, _, _ := p.parameters()
+	return err == nil && p.check(FatArrow) && p.current != p.guardArrow
+}
+
+func (p *Parser) lambda() (error, Expression) {
+	name := NewToken(AnonymusFunction, AnonymusFunction, "", p.previous().line, p.previous().column)
+	parametersErr, parameters, defaults, rest := p.parameters()
+	if parametersErr != nil {
+		return parametersErr, nil
+	}
+	arrowErr, _ := p.consume(FatArrow, "Expected '=>' after lambda parameters")
+	if arrowErr != nil {
+		return arrowErr, nil
+	}
+	if p.match(LeftCurlyBracket) {
+		err, body := p.block()
+		if err != nil {
+			return err, nil
+		}
+		return nil, (NewFunction(name, parameters, defaults, rest, body))
+	}
+	err, value := p.expression()
+	if err != nil {
+		return err, nil
+	}
+	body := []Statement{NewReturnStatement(value)}
+	return nil, (NewFunction(name, parameters, defaults, rest, body))
+}
+
+func (p *Parser) interpolation() (error, Expression) {
+	parts := []Expression{NewLiteral(p.previous().literal)}
+	for {
+		err, expression := p.expression()
+		if err != nil {
+			return err, nil
+		}
+		parts = append(parts, expression)
+		if p.match(Interpolation) {
+			parts = append(parts, NewLiteral(p.previous().literal))
+			continue
+		}
+		consumeErr, end := p.consume(String, "Expected '}' after interpolated expression")
+		if consumeErr != nil {
+			return consumeErr, nil
+		}
+		parts = append(parts, NewLiteral(end.literal))
+		return nil, (NewInterpolatedString(parts))
+	}
+}
+
+func (p *Parser) arrayLiteral() (error, Expression) {
+	elements := []Expression{}
+	if !p.check(RightBracket) {
+		for {
+			var ellipsis *Token
+			if p.match(DotDotDot) {
+				ellipsis = p.previous()
+			}
+			err, element := p.expression()
+			if err != nil {
+				return err, nil
+			}
+			if ellipsis != nil {
+				element = NewSpread(ellipsis, element)
+			}
+			elements = append(elements, element)
+			if !p.match(Comma) {
+				break
+			}
+		}
+	}
+	consumeErr, _ := p.consume(RightBracket, "Expected ']' after array elements")
+	if consumeErr != nil {
+		return consumeErr, nil
+	}
+	return nil, (NewArrayLiteral(elements))
+}
+
+func (p *Parser) mapLiteral() (error, Expression) {
+	keys := []Expression{}
+	values := []Expression{}
+	if !p.check(RightCurlyBracket) {
+		for {
+			keyErr, key := p.expression()
+			if keyErr != nil {
+				return keyErr, nil
+			}
+			colonErr, _ := p.consume(Colon, "Expected ':' after map key")
+			if colonErr != nil {
+				return colonErr, nil
+			}
+			valueErr, value := p.expression()
+			if valueErr != nil {
+				return valueErr, nil
+			}
+			keys = append(keys, key)
+			values = append(values, value)
+			if !p.match(Comma) {
+				break
+			}
+		}
+	}
+	consumeErr, _ := p.consume(RightCurlyBracket, "Expected '}' after map entries")
+	if consumeErr != nil {
+		return consumeErr, nil
+	}
+	return nil, (NewMapLiteral(keys, values))
+}
+
 func (p *Parser) consume(tokenType string, message string) (error, *Token) {
 	if p.check(tokenType) {
 		return nil, p.advance()
@@ -312,6 +894,13 @@ func (p *Parser) check(tokenType string) bool {
 	return p.peek().tokenType == tokenType
 }
 
+func (p Parser) checkNext(tokenType string) bool {
+	if p.current+1 >= len(p.tokens) {
+		return false
+	}
+	return p.tokens[p.current+1].tokenType == tokenType
+}
+
 func (p *Parser) advance() *Token {
 	if !p.isAtEnd() {
 		p.current++
@@ -420,7 +1009,7 @@ func (p *Parser) ifStatement() (error, Statement) {
 	return nil, (NewIfStatement(condition, thenBranch, elseBranch))
 }
 
-func (p *Parser) whileStatement() (error, Statement) {
+func (p *Parser) whileStatement(label *Token) (error, Statement) {
 	consumeErrLeft, _ := p.consume(LeftBrace, "Expected '('")
 	if consumeErrLeft != nil {
 		return consumeErrLeft, nil
@@ -437,18 +1026,21 @@ func (p *Parser) whileStatement() (error, Statement) {
 	if err != nil {
 		return err, nil
 	}
-	return nil, (NewWhileStatement(condition, statement))
+	return nil, (NewWhileStatement(label, condition, statement))
 }
 
-func (p *Parser) forStatement() (error, Statement) {
+func (p *Parser) forStatement(label *Token) (error, Statement) {
 	consumeErrLeft, _ := p.consume(LeftBrace, "Expected '('")
 	if consumeErrLeft != nil {
 		return consumeErrLeft, nil
 	}
+	if p.check(Identifier) && p.checkNext(In) {
+		return p.forInStatement(label)
+	}
 	if !p.match(Let) {
 		return NewParserError("Let expected"), nil
 	}
-	err, initializer := p.letDeclaration()
+	err, initializer := p.letDeclaration(false)
 	if err != nil {
 		return err, nil
 	}
@@ -472,7 +1064,25 @@ func (p *Parser) forStatement() (error, Statement) {
 	if err != nil {
 		return err, nil
 	}
-	return nil, (NewForStatement(condition, initializer, increment, body))
+	return nil, (NewForStatement(label, condition, initializer, increment, body))
+}
+
+func (p *Parser) forInStatement(label *Token) (error, Statement) {
+	variable := p.advance()
+	p.advance()
+	err, iterable := p.expression()
+	if err != nil {
+		return err, nil
+	}
+	consumeErr, _ := p.consume(RightBrace, "Expected ')' after for-in iterable")
+	if consumeErr != nil {
+		return consumeErr, nil
+	}
+	bodyErr, body := p.statement()
+	if bodyErr != nil {
+		return bodyErr, nil
+	}
+	return nil, (NewForInStatement(label, variable, iterable, body))
 }
 
 func (p *Parser) returnStatement() (error, Statement) {
@@ -492,11 +1102,14 @@ func (p *Parser) returnStatement() (error, Statement) {
 }
 
 func (p *Parser) statement() (error, Statement) {
+	if p.check(Identifier) && p.checkNext(Colon) {
+		return p.labeledStatement()
+	}
 	if p.match(For) {
-		return p.forStatement()
+		return p.forStatement(nil)
 	}
 	if p.match(While) {
-		return p.whileStatement()
+		return p.whileStatement(nil)
 	}
 	if p.match(If) {
 		return p.ifStatement()
@@ -514,27 +1127,112 @@ func (p *Parser) statement() (error, Statement) {
 	if p.match(Return) {
 		return p.returnStatement()
 	}
+	if p.match(Try) {
+		return p.tryStatement()
+	}
+	if p.match(Throw) {
+		keyword := p.previous()
+		err, value := p.expression()
+		if err != nil {
+			return err, nil
+		}
+		consumeError, _ := p.consume(Semicolon, "Expected ';' after thrown value")
+		if consumeError != nil {
+			return consumeError, nil
+		}
+		return nil, (NewThrowStatement(keyword, value))
+	}
 	if p.match(Break) {
+		keyword := p.previous()
+		label := p.jumpLabel()
 		consumeError, _ := p.consume(Semicolon, "Expected ';' after value")
 		if consumeError != nil {
 			return consumeError, nil
 		}
-		return nil, (NewBreakStatement())
+		return nil, (NewBreakStatement(keyword, label))
 	}
 	if p.match(Continue) {
+		keyword := p.previous()
+		label := p.jumpLabel()
 		consumeError, _ := p.consume(Semicolon, "Expected ';' after value")
 		if consumeError != nil {
 			return consumeError, nil
 		}
-		return nil, (NewContinueStatement())
+		return nil, (NewContinueStatement(keyword, label))
 	}
 	return p.expressionStatement()
 }
 
-func (p *Parser) letDeclaration() (error, Statement) {
-	identifierErr, name := p.consume(Identifier, "Variable name expected")
-	if identifierErr != nil {
-		return identifierErr, nil
+func (p *Parser) tryStatement() (error, Statement) {
+	bodyErr, body := p.tryBlock("try")
+	if bodyErr != nil {
+		return bodyErr, nil
+	}
+	var catchName *Token
+	var catchBody []Statement
+	if p.match(Catch) {
+		if p.match(LeftBrace) {
+			nameErr, name := p.consume(Identifier, "Expected error name after 'catch ('")
+			if nameErr != nil {
+				return nameErr, nil
+			}
+			rightErr, _ := p.consume(RightBrace, "Expected ')' after error name")
+			if rightErr != nil {
+				return rightErr, nil
+			}
+			catchName = name
+		}
+		catchErr, statements := p.tryBlock("catch")
+		if catchErr != nil {
+			return catchErr, nil
+		}
+		catchBody = statements
+	}
+	var finallyBody []Statement
+	if p.match(Finally) {
+		finallyErr, statements := p.tryBlock("finally")
+		if finallyErr != nil {
+			return finallyErr, nil
+		}
+		finallyBody = statements
+	}
+	if catchBody == nil && finallyBody == nil {
+		return NewParserError("Expected 'catch' or 'finally' after try block"), nil
+	}
+	return nil, (NewTryStatement(body, catchName, catchBody, finallyBody))
+}
+
+func (p *Parser) tryBlock(clause string) (error, []Statement) {
+	consumeErr, _ := p.consume(LeftCurlyBracket, "Expected '{' after '"+clause+"'")
+	if consumeErr != nil {
+		return consumeErr, nil
+	}
+	return p.block()
+}
+
+func (p *Parser) labeledStatement() (error, Statement) {
+	label := p.advance()
+	p.advance()
+	if p.match(For) {
+		return p.forStatement(label)
+	}
+	if p.match(While) {
+		return p.whileStatement(label)
+	}
+	return NewParserError("Expected loop after label '%v'", label.lexeme), nil
+}
+
+func (p *Parser) jumpLabel() *Token {
+	if p.match(Identifier) {
+		return p.previous()
+	}
+	return nil
+}
+
+func (p *Parser) letDeclaration(constant bool) (error, Statement) {
+	patternErr, pattern := p.bindingPattern()
+	if patternErr != nil {
+		return patternErr, nil
 	}
 	var initializer Expression
 	if p.match(Equal) {
@@ -544,18 +1242,88 @@ func (p *Parser) letDeclaration() (error, Statement) {
 		}
 		initializer = expression
 	}
+	if constant && initializer == nil {
+		return NewParserError("Constant declaration needs an initializer"), nil
+	}
+	if _, ok := pattern.(*BindingPattern); !ok && initializer == nil {
+		return NewParserError("Destructuring declaration needs an initializer"), nil
+	}
 	err, _ := p.consume(Semicolon, "Expected ';' after variable declaration.")
 	if err != nil {
 		return err, nil
 	}
-	return nil, (NewLetStatement(name, initializer))
+	return nil, (NewLetStatement(pattern, initializer, constant))
+}
+
+func (p *Parser) bindingPattern() (error, Pattern) {
+	if p.match(LeftBracket) {
+		elements := []Pattern{}
+		var rest Pattern
+		for !p.check(RightBracket) {
+			if p.match(DotDotDot) {
+				restErr, name := p.consume(Identifier, "Expected name after '...'")
+				if restErr != nil {
+					return restErr, nil
+				}
+				rest = NewBindingPattern(name)
+				break
+			}
+			err, element := p.bindingPattern()
+			if err != nil {
+				return err, nil
+			}
+			elements = append(elements, element)
+			if !p.match(Comma) {
+				break
+			}
+		}
+		consumeErr, _ := p.consume(RightBracket, "Expected ']' after destructuring pattern")
+		if consumeErr != nil {
+			return consumeErr, nil
+		}
+		return nil, NewArrayPattern(elements, rest)
+	}
+	if p.match(LeftCurlyBracket) {
+		fields := []*Token{}
+		values := []Pattern{}
+		for !p.check(RightCurlyBracket) {
+			fieldErr, field := p.consume(Identifier, "Expected field name in destructuring pattern")
+			if fieldErr != nil {
+				return fieldErr, nil
+			}
+			var value Pattern = NewBindingPattern(field)
+			if p.match(Colon) {
+				valueErr, pattern := p.bindingPattern()
+				if valueErr != nil {
+					return valueErr, nil
+				}
+				value = pattern
+			}
+			fields = append(fields, field)
+			values = append(values, value)
+			if !p.match(Comma) {
+				break
+			}
+		}
+		consumeErr, _ := p.consume(RightCurlyBracket, "Expected '}' after destructuring pattern")
+		if consumeErr != nil {
+			return consumeErr, nil
+		}
+		return nil, NewObjectPattern(fields, values)
+	}
+	identifierErr, name := p.consume(Identifier, "Variable name expected")
+	if identifierErr != nil {
+		return identifierErr, nil
+	}
+	return nil, NewBindingPattern(name)
 }
 
 func (p *Parser) function() (error, Expression) {
 	if !p.match(Fn) {
 		return p.call()
 	}
-	name := NewToken(AnonymusFunction, AnonymusFunction, "", 0)
+	keyword := p.previous()
+	name := NewToken(AnonymusFunction, AnonymusFunction, "", 0, 0)
 	if p.check(Identifier) {
 		identifierErr, token := p.consume(Identifier, "Function name expected")
 		if identifierErr != nil {
@@ -563,21 +1331,65 @@ func (p *Parser) function() (error, Expression) {
 		}
 		name = token
 	}
+	name.trivia = keyword.trivia
 	leftBraceErr, _ := p.consume(LeftBrace, "Expected '('")
 	if leftBraceErr != nil {
 		return leftBraceErr, nil
 	}
+	parametersErr, parameters, defaults, rest := p.parameters()
+	if parametersErr != nil {
+		return parametersErr, nil
+	}
+	leftCurlyBracketErr, _ := p.consume(LeftCurlyBracket, "Expected '{' before body function")
+	if leftCurlyBracketErr != nil {
+		return leftCurlyBracketErr, nil
+	}
+	err, body := p.block()
+	if err != nil {
+		return err, nil
+	}
+	return nil, (NewFunction(name, parameters, defaults, rest, body))
+}
+
+// parameters parses a parameter list up to and including the closing ')'.
+func (p *Parser) parameters() (error, []*Token, []Expression, *Token) {
 	parameters := []*Token{}
+	defaults := []Expression{}
+	hasDefaults := false
+	var rest *Token
 	if !p.check(RightBrace) {
 		for {
 			if len(parameters) > MAX_FN_ARGUMENTS_COUNT {
-				return NewParserError("Can't have more than %v parameters", MAX_FN_ARGUMENTS_COUNT), nil
+				return NewParserError("Can't have more than %v parameters", MAX_FN_ARGUMENTS_COUNT), nil, nil, nil
+			}
+			if p.match(DotDotDot) {
+				restErr, identifier := p.consume(Identifier, "Expected rest parameter name after '...'")
+				if restErr != nil {
+					return restErr, nil, nil, nil
+				}
+				rest = identifier
+				if !p.check(RightBrace) {
+					return NewParserError("Rest parameter '%v' must be the last parameter", rest.lexeme), nil, nil, nil
+				}
+				break
 			}
 			consumeErr, identifier := p.consume(Identifier, "Expected parameter name")
 			if consumeErr != nil {
-				return consumeErr, nil
+				return consumeErr, nil, nil, nil
+			}
+			var defaultValue Expression
+			if p.match(Equal) {
+				defaultErr, expression := p.expression()
+				if defaultErr != nil {
+					return defaultErr, nil, nil, nil
+				}
+				defaultValue = expression
+				hasDefaults = true
+			} else if hasDefaults {
+				return NewParserError("Parameter '%v' without a default can't follow parameters with defaults", identifier.lexeme), nil, nil, nil
 			}
 			parameters = append(parameters, identifier)
+			defaults = append(defaults, defaultValue)
 			if !p.match(Comma) {
 				break
 			}
@@ -585,17 +1397,9 @@ func (p *Parser) function() (error, Expression) {
 	}
 	rightBraceErr, _ := p.consume(RightBrace, "Expected ')' after parameters")
 	if rightBraceErr != nil {
-		return rightBraceErr, nil
-	}
-	leftCurlyBracketErr, _ := p.consume(LeftCurlyBracket, "Expected '{' before body function")
-	if leftCurlyBracketErr != nil {
-		return leftCurlyBracketErr, nil
-	}
-	err, body := p.block()
-	if err != nil {
-		return err, nil
+		return rightBraceErr, nil, nil, nil
 	}
-	return nil, (NewFunction(name, parameters, body))
+	return nil, parameters, defaults, rest
 }
 
 func (p *Parser) structDeclaration() (error, Statement) {
@@ -603,28 +1407,108 @@ func (p *Parser) structDeclaration() (error, Statement) {
 	if identifierErr != nil {
 		return identifierErr, nil
 	}
+	var superstruct *Variable
+	if p.match(Less) {
+		superstructErr, superstructName := p.consume(Identifier, "Expected superstruct name")
+		if superstructErr != nil {
+			return superstructErr, nil
+		}
+		superstruct = NewVariable(superstructName)
+	}
 	leftCurlyBracketErr, _ := p.consume(LeftCurlyBracket, "Expected '{' before class body.")
 	if leftCurlyBracketErr != nil {
 		return leftCurlyBracketErr, nil
 	}
 	methods := []*Function{}
+	staticMethods := []*Function{}
+	staticFields := []*LetStatement{}
+	getters := []*Function{}
+	setters := []*Function{}
 	for !p.check(RightCurlyBracket) && !p.isAtEnd() {
+		if p.match(Identifier) {
+			err, accessor := p.accessor()
+			if err != nil {
+				return err, nil
+			}
+			if len(accessor.parameters) == 0 {
+				getters = append(getters, accessor)
+			} else {
+				setters = append(setters, accessor)
+			}
+			continue
+		}
+		isStatic := p.match(Static)
+		keyword := p.previous()
+		if isStatic && p.match(Let) {
+			err, field := p.letDeclaration(false)
+			if err != nil {
+				return err, nil
+			}
+			if _, ok := field.(*LetStatement).pattern.(*BindingPattern); !ok {
+				return NewParserError("Static fields can't be destructured"), nil
+			}
+			staticFields = append(staticFields, field.(*LetStatement))
+			continue
+		}
+		if !p.check(Fn) {
+			return NewParserError("Expected method declaration in struct body"), nil
+		}
 		err, fn := p.function()
 		if err != nil {
 			return err, nil
 		}
-		if value, ok := fn.(*Function); ok {
-			methods = append(methods, value)
-		} else {
+		value, ok := fn.(*Function)
+		if !ok {
 			return NewParserError("Unexpected expression returned as function method"), nil
 		}
+		if isStatic {
+			value.name.trivia = keyword.trivia
+			staticMethods = append(staticMethods, value)
+		} else {
+			methods = append(methods, value)
+		}
 	}
 
 	rightCurlyBracketErr, _ := p.consume(RightCurlyBracket, "Expect '}' after class body.")
 	if rightCurlyBracketErr != nil {
 		return rightCurlyBracketErr, nil
 	}
-	return nil, NewStructStatment(name, methods)
+	return nil, NewStructStatment(name, superstruct, methods, staticMethods, staticFields, getters, setters)
+}
+
+func (p *Parser) accessor() (error, *Function) {
+	name := p.previous()
+	parameters := []*Token{}
+	if name.lexeme == SetAccessor && p.check(Identifier) {
+		identifierErr, property := p.consume(Identifier, "Expected property name")
+		if identifierErr != nil {
+			return identifierErr, nil
+		}
+		property.trivia = name.trivia
+		name = property
+		leftBraceErr, _ := p.consume(LeftBrace, "Expected '(' after setter name")
+		if leftBraceErr != nil {
+			return leftBraceErr, nil
+		}
+		parameterErr, parameter := p.consume(Identifier, "Expected setter parameter name")
+		if parameterErr != nil {
+			return parameterErr, nil
+		}
+		parameters = append(parameters, parameter)
+		rightBraceErr, _ := p.consume(RightBrace, "Setter must have exactly one parameter")
+		if rightBraceErr != nil {
+			return rightBraceErr, nil
+		}
+	}
+	leftCurlyBracketErr, _ := p.consume(LeftCurlyBracket, "Expected '{' before accessor body")
+	if leftCurlyBracketErr != nil {
+		return leftCurlyBracketErr, nil
+	}
+	err, body := p.block()
+	if err != nil {
+		return err, nil
+	}
+	return nil, NewFunction(name, parameters, make([]Expression, len(parameters)), nil, body)
 }
 
 func (p *Parser) declaration() (error, Statement) {
@@ -632,7 +1516,10 @@ func (p *Parser) declaration() (error, Statement) {
 		return p.structDeclaration()
 	}
 	if p.match(Let) {
-		return p.letDeclaration()
+		return p.letDeclaration(false)
+	}
+	if p.match(Const) {
+		return p.letDeclaration(true)
 	}
 	err, statement := p.statement()
 	if err != nil {
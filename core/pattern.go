@@ -0,0 +1,120 @@
+package main
+
+const WildcardName = "_"
+
+type Pattern = any
+
+type WildcardPattern struct {
+	token *Token
+}
+
+func NewWildcardPattern(token *Token) *WildcardPattern {
+	return &WildcardPattern{
+		token,
+	}
+}
+
+type LiteralPattern struct {
+	value any
+}
+
+func NewLiteralPattern(value any) *LiteralPattern {
+	return &LiteralPattern{
+		value,
+	}
+}
+
+type BindingPattern struct {
+	name *Token
+}
+
+func NewBindingPattern(name *Token) *BindingPattern {
+	return &BindingPattern{
+		name,
+	}
+}
+
+type AlternativePattern struct {
+	alternatives []Pattern
+}
+
+func NewAlternativePattern(alternatives []Pattern) *AlternativePattern {
+	return &AlternativePattern{
+		alternatives,
+	}
+}
+
+type StructPattern struct {
+	name   *Variable
+	fields []*Token
+	values []Pattern
+}
+
+func NewStructPattern(name *Variable, fields []*Token, values []Pattern) *StructPattern {
+	return &StructPattern{
+		name,
+		fields,
+		values,
+	}
+}
+
+// ArrayPattern destructures the values of an iterable. rest collects the values
+// left over, and is nil when there must be none.
+type ArrayPattern struct {
+	elements []Pattern
+	rest     Pattern
+}
+
+func NewArrayPattern(elements []Pattern, rest Pattern) *ArrayPattern {
+	return &ArrayPattern{
+		elements,
+		rest,
+	}
+}
+
+type ObjectPattern struct {
+	fields []*Token
+	values []Pattern
+}
+
+func NewObjectPattern(fields []*Token, values []Pattern) *ObjectPattern {
+	return &ObjectPattern{
+		fields,
+		values,
+	}
+}
+
+// TargetPattern assigns to an existing variable, property or index.
+type TargetPattern struct {
+	target Expression
+}
+
+func NewTargetPattern(target Expression) *TargetPattern {
+	return &TargetPattern{
+		target,
+	}
+}
+
+// patternNames returns every name a declaration pattern binds.
+func patternNames(pattern Pattern) []*Token {
+	switch option := pattern.(type) {
+	case *BindingPattern:
+		return []*Token{option.name}
+	case *ArrayPattern:
+		names := []*Token{}
+		for _, element := range option.elements {
+			names = append(names, patternNames(element)...)
+		}
+		if option.rest != nil {
+			names = append(names, patternNames(option.rest)...)
+		}
+		return names
+	case *ObjectPattern:
+		names := []*Token{}
+		for _, value := range option.values {
+			names = append(names, patternNames(value)...)
+		}
+		return names
+	}
+	return []*Token{}
+}
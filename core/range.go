@@ -0,0 +1,137 @@
+package main
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+)
+
+const StepKeyword = "step"
+
+// GSRange is a lazy sequence of integers from start towards end, moving by
+// step. Exclusive ranges stop before end, inclusive ones include it.
+type GSRange struct {
+	start     int64
+	end       int64
+	step      int64
+	inclusive bool
+}
+
+func NewGSRange(start int64, end int64, step int64, inclusive bool) *GSRange {
+	return &GSRange{
+		start,
+		end,
+		step,
+		inclusive,
+	}
+}
+
+func (r GSRange) String() string {
+	text := strconv.FormatInt(r.start, 10)
+	if r.inclusive {
+		text += "..="
+	} else {
+		text += ".."
+	}
+	text += strconv.FormatInt(r.end, 10)
+	if r.step != 1 {
+		text += " " + StepKeyword + " " + strconv.FormatInt(r.step, 10)
+	}
+	return text
+}
+
+// last returns the bound the range may reach, or false when the range is empty.
+func (r *GSRange) last() (int64, bool) {
+	last := r.end
+	if !r.inclusive {
+		if r.step > 0 {
+			if last == math.MinInt64 {
+				return 0, false
+			}
+			last--
+		} else {
+			if last == math.MaxInt64 {
+				return 0, false
+			}
+			last++
+		}
+	}
+	if (r.step > 0 && last < r.start) || (r.step < 0 && last > r.start) {
+		return 0, false
+	}
+	return last, true
+}
+
+// distance returns how far from lies behind to in the direction of the step.
+func (r *GSRange) distance(from int64, to int64) uint64 {
+	if r.step > 0 {
+		return uint64(to) - uint64(from)
+	}
+	return uint64(from) - uint64(to)
+}
+
+func (r *GSRange) stride() uint64 {
+	if r.step > 0 {
+		return uint64(r.step)
+	}
+	return uint64(-r.step)
+}
+
+func (r *GSRange) length() any {
+	last, ok := r.last()
+	if !ok {
+		return int64(0)
+	}
+	count := new(big.Int).SetUint64(r.distance(r.start, last) / r.stride())
+	return promote(count.Add(count, big.NewInt(1)))
+}
+
+func (r *GSRange) contains(value any) bool {
+	number, ok := value.(int64)
+	if !ok {
+		return false
+	}
+	last, ok := r.last()
+	if !ok {
+		return false
+	}
+	if (r.step > 0 && (number < r.start || number > last)) || (r.step < 0 && (number > r.start || number < last)) {
+		return false
+	}
+	return r.distance(r.start, number)%r.stride() == 0
+}
+
+func (r *GSRange) iterator() GSIterator {
+	last, ok := r.last()
+	current := r.start
+	done := !ok
+	return func() (error, any, bool) {
+		if done {
+			return nil, nil, false
+		}
+		value := current
+		if r.distance(value, last) < r.stride() {
+			done = true
+		} else {
+			current += r.step
+		}
+		return nil, value, true
+	}
+}
+
+// slice picks the elements at the positions of the range.
+func (r *GSRange) slice(elements []any) (error, []any) {
+	result := []any{}
+	next := r.iterator()
+	for {
+		_, index, ok := next()
+		if !ok {
+			return nil, result
+		}
+		position := index.(int64)
+		if position < 0 || position >= int64(len(elements)) {
+			return NewRuntimeError("Slice index %v out of range [0, %v)", position, len(elements)), nil
+		}
+		result = append(result, elements[position])
+	}
+}
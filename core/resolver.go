@@ -2,29 +2,54 @@ package main
 
 import (
 	"fmt"
+	"slices"
+)
+
+const (
+	StructTypeNone      = "none"
+	StructTypeStruct    = "struct"
+	StructTypeSubstruct = "substruct"
+)
+
+const (
+	FunctionTypeNone        = "none"
+	FunctionTypeFunction    = "function"
+	FunctionTypeMethod      = "method"
+	FunctionTypeInitializer = "initializer"
 )
 
 type Resolver struct {
-	locals map[Expression]int
-	scopes []map[string]bool
+	locals          map[Expression]int
+	scopes          []map[string]bool
+	constants       []map[string]bool
+	currentStruct   string
+	currentFunction string
+	loops           []string
 }
 
 func NewResolver() *Resolver {
 	scopes := []map[string]bool{}
+	constants := []map[string]bool{}
 	locals := map[Expression]int{}
 	return &Resolver{
 		locals,
 		scopes,
+		constants,
+		StructTypeNone,
+		FunctionTypeNone,
+		[]string{},
 	}
 }
 
 func (r *Resolver) beginScope() {
 	r.scopes = append(r.scopes, map[string]bool{})
+	r.constants = append(r.constants, map[string]bool{})
 }
 
 func (r *Resolver) endScope() {
 	if len(r.scopes) > 0 {
 		r.scopes = r.scopes[:len(r.scopes)-1]
+		r.constants = r.constants[:len(r.constants)-1]
 	} else {
 		fmt.Print("Warning: empty scopes")
 	}
@@ -40,6 +65,28 @@ func (r *Resolver) declare(name *Token) {
 	}
 	scope := r.scopes[len(r.scopes)-1]
 	scope[name.lexeme] = false
+	r.constants[len(r.constants)-1][name.lexeme] = false
+}
+
+func (r *Resolver) defineConstant(name *Token) {
+	r.define(name)
+	if !r.isScopesEmpty() {
+		r.constants[len(r.constants)-1][name.lexeme] = true
+	}
+}
+
+// checkAssignable reports an assignment to a local constant. Global constants
+// are checked when the assignment runs.
+func (r *Resolver) checkAssignable(name *Token) error {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if _, ok := r.scopes[i][name.lexeme]; ok {
+			if r.constants[i][name.lexeme] {
+				return NewResolveError("Can't assign to constant '%v'.", name.lexeme)
+			}
+			return nil
+		}
+	}
+	return nil
 }
 
 func (r *Resolver) define(name *Token) {
@@ -61,28 +108,216 @@ func (r *Resolver) resolveLocal(expression Expression, name *Token) {
 	}
 }
 
-func (r *Resolver) resolveFunction(fn *Function) error {
+func (r *Resolver) resolveFunction(fn *Function, functionType string) error {
+	enclosingFunction := r.currentFunction
+	enclosingLoops := r.loops
+	r.currentFunction = functionType
+	r.loops = []string{}
 	r.beginScope()
-	for _, parameter := range fn.parameters {
+	for index, parameter := range fn.parameters {
+		if fn.defaults[index] != nil {
+			err := r.resolveExpression(fn.defaults[index])
+			if err != nil {
+				return err
+			}
+		}
 		r.declare(parameter)
 		r.define(parameter)
 	}
+	if fn.rest != nil {
+		r.declare(fn.rest)
+		r.define(fn.rest)
+	}
 	err, _ := r.resolve(fn.body)
 	if err != nil {
 		return err
 	}
 	r.endScope()
+	r.currentFunction = enclosingFunction
+	r.loops = enclosingLoops
+	return nil
+}
+
+// resolveLoop resolves a loop body, keeping track of the enclosing loop labels
+// so that break and continue can be checked.
+func (r *Resolver) resolveLoop(label *Token, body Statement) error {
+	name := labelName(label)
+	if name != "" && slices.Contains(r.loops, name) {
+		return NewResolveError("Label '%v' is already used by an enclosing loop.", name)
+	}
+	enclosingLoops := r.loops
+	r.loops = append(r.loops, name)
+	err := r.resolveStatement(body)
+	r.loops = enclosingLoops
+	return err
+}
+
+func (r *Resolver) resolveJump(keyword *Token, label *Token) error {
+	if len(r.loops) == 0 {
+		return NewResolveError("Can't use '%v' outside of a loop.", keyword.lexeme)
+	}
+	if label != nil && !slices.Contains(r.loops, label.lexeme) {
+		return NewResolveError("Undefined loop label '%v'.", label.lexeme)
+	}
+	return nil
+}
+
+func (r *Resolver) resolveTarget(expression Expression, target Expression) error {
+	switch option := (target).(type) {
+	case *Variable:
+		err := r.checkAssignable(option.name)
+		if err != nil {
+			return err
+		}
+		r.resolveLocal(expression, option.name)
+		return nil
+	case *Get:
+		return r.resolveExpression(option.object)
+	case *GetIndex:
+		objectErr := r.resolveExpression(option.object)
+		if objectErr != nil {
+			return objectErr
+		}
+		return r.resolveExpression(option.index)
+	}
+	return nil
+}
+
+// resolvePattern declares the names a match pattern binds in the current scope.
+func (r *Resolver) resolvePattern(pattern Pattern) error {
+	switch option := pattern.(type) {
+	case *BindingPattern:
+		if r.scopes[len(r.scopes)-1][option.name.lexeme] {
+			return NewResolveError("Duplicate binding '%v' in pattern.", option.name.lexeme)
+		}
+		r.declare(option.name)
+		r.define(option.name)
+	case *AlternativePattern:
+		for _, alternative := range option.alternatives {
+			if patternBindsNames(alternative) {
+				return NewResolveError("Alternative patterns can't bind names.")
+			}
+			err := r.resolvePattern(alternative)
+			if err != nil {
+				return err
+			}
+		}
+	case *StructPattern:
+		err := r.resolveExpression(option.name)
+		if err != nil {
+			return err
+		}
+		for _, value := range option.values {
+			err := r.resolvePattern(value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func patternBindsNames(pattern Pattern) bool {
+	switch option := pattern.(type) {
+	case *BindingPattern:
+		return true
+	case *AlternativePattern:
+		for _, alternative := range option.alternatives {
+			if patternBindsNames(alternative) {
+				return true
+			}
+		}
+	case *StructPattern:
+		for _, value := range option.values {
+			if patternBindsNames(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *Resolver) resolveTargetPattern(pattern Pattern) error {
+	switch option := pattern.(type) {
+	case *TargetPattern:
+		return r.resolveTarget(option.target, option.target)
+	case *ArrayPattern:
+		for _, element := range option.elements {
+			err := r.resolveTargetPattern(element)
+			if err != nil {
+				return err
+			}
+		}
+		if option.rest != nil {
+			return r.resolveTargetPattern(option.rest)
+		}
+	}
 	return nil
 }
 
 func (r *Resolver) resolveExpression(expression Expression) error {
 	switch option := (expression).(type) {
 	case *Get:
-		r.resolveExpression(option.object)
-		return nil
+		return r.resolveExpression(option.object)
 	case *Set:
-		r.resolveExpression(option.value)
-		r.resolveExpression(option.object)
+		valueErr := r.resolveExpression(option.value)
+		if valueErr != nil {
+			return valueErr
+		}
+		return r.resolveExpression(option.object)
+	case *Ternary:
+		leftErr := r.resolveExpression(option.left)
+		if leftErr != nil {
+			return leftErr
+		}
+		middleErr := r.resolveExpression(option.middle)
+		if middleErr != nil {
+			return middleErr
+		}
+		return r.resolveExpression(option.right)
+	case *ArrayLiteral:
+		for _, element := range option.elements {
+			err := r.resolveExpression(element)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case *MapLiteral:
+		for index, key := range option.keys {
+			keyErr := r.resolveExpression(key)
+			if keyErr != nil {
+				return keyErr
+			}
+			valueErr := r.resolveExpression(option.values[index])
+			if valueErr != nil {
+				return valueErr
+			}
+		}
+		return nil
+	case *GetIndex:
+		objectErr := r.resolveExpression(option.object)
+		if objectErr != nil {
+			return objectErr
+		}
+		return r.resolveExpression(option.index)
+	case *SetIndex:
+		valueErr := r.resolveExpression(option.value)
+		if valueErr != nil {
+			return valueErr
+		}
+		objectErr := r.resolveExpression(option.object)
+		if objectErr != nil {
+			return objectErr
+		}
+		return r.resolveExpression(option.index)
+	case *InterpolatedString:
+		for _, part := range option.parts {
+			err := r.resolveExpression(part)
+			if err != nil {
+				return err
+			}
+		}
 		return nil
 	case *Grouping:
 		return r.resolveExpression(option.expression)
@@ -112,6 +347,8 @@ func (r *Resolver) resolveExpression(expression Expression) error {
 			}
 		}
 		return nil
+	case *Spread:
+		return r.resolveExpression(option.expression)
 	case *Binary:
 		errLeft := r.resolveExpression(option.left)
 		if errLeft != nil {
@@ -125,13 +362,22 @@ func (r *Resolver) resolveExpression(expression Expression) error {
 	case *Function:
 		r.declare(option.name)
 		r.define(option.name)
-		err := r.resolveFunction(option)
+		err := r.resolveFunction(option, FunctionTypeFunction)
 		if err != nil {
 			return err
 		}
 	case *ThisExpression:
 		r.resolveLocal(expression, option.keyword)
 		return nil
+	case *SuperExpression:
+		if r.currentStruct == StructTypeNone {
+			return NewResolveError("Can't use 'super' outside of a struct.")
+		}
+		if r.currentStruct != StructTypeSubstruct {
+			return NewResolveError("Can't use 'super' in a struct with no superstruct.")
+		}
+		r.resolveLocal(expression, option.keyword)
+		return nil
 	case *Variable:
 		if !r.isScopesEmpty() {
 			scope := r.scopes[len(r.scopes)-1]
@@ -147,7 +393,58 @@ func (r *Resolver) resolveExpression(expression Expression) error {
 		if err != nil {
 			return err
 		}
+		constantErr := r.checkAssignable(option.name)
+		if constantErr != nil {
+			return constantErr
+		}
 		r.resolveLocal(expression, option.name)
+	case *DestructuringAssignment:
+		err := r.resolveExpression(option.value)
+		if err != nil {
+			return err
+		}
+		return r.resolveTargetPattern(option.pattern)
+	case *CompoundAssignment:
+		err := r.resolveExpression(option.value)
+		if err != nil {
+			return err
+		}
+		return r.resolveTarget(expression, option.target)
+	case *Update:
+		return r.resolveTarget(expression, option.target)
+	case *RangeExpression:
+		for _, bound := range []Expression{option.start, option.end, option.step} {
+			if bound == nil {
+				continue
+			}
+			err := r.resolveExpression(bound)
+			if err != nil {
+				return err
+			}
+		}
+	case *MatchExpression:
+		err := r.resolveExpression(option.subject)
+		if err != nil {
+			return err
+		}
+		for _, arm := range option.arms {
+			r.beginScope()
+			patternErr := r.resolvePattern(arm.pattern)
+			if patternErr != nil {
+				return patternErr
+			}
+			if arm.guard != nil {
+				guardErr := r.resolveExpression(arm.guard)
+				if guardErr != nil {
+					return guardErr
+				}
+			}
+			bodyErr := r.resolveExpression(arm.body)
+			if bodyErr != nil {
+				return bodyErr
+			}
+			r.endScope()
+		}
 	}
 	return nil
 }
@@ -155,33 +452,116 @@ func (r *Resolver) resolveExpression(expression Expression) error {
 func (r *Resolver) resolveStatement(statement Statement) error {
 	switch option := (statement).(type) {
 	case *StructStatment:
+		enclosingStruct := r.currentStruct
+		r.currentStruct = StructTypeStruct
 		r.declare(option.name)
 		r.define(option.name)
 
+		if option.superstruct != nil {
+			if option.superstruct.name.lexeme == option.name.lexeme {
+				return NewResolveError("A struct can't inherit from itself.")
+			}
+			r.currentStruct = StructTypeSubstruct
+			err := r.resolveExpression(option.superstruct)
+			if err != nil {
+				return err
+			}
+			r.beginScope()
+			superScope := r.scopes[len(r.scopes)-1]
+			superScope[Super] = true
+		}
+
 		r.beginScope()
 		scope := r.scopes[len(r.scopes)-1]
 		scope[This] = true
 
 		for _, method := range option.methods {
-			err := r.resolveFunction(method)
+			functionType := FunctionTypeMethod
+			if method.name.lexeme == InitMethod {
+				functionType = FunctionTypeInitializer
+			}
+			err := r.resolveFunction(method, functionType)
+			if err != nil {
+				return err
+			}
+		}
+		for _, method := range option.staticMethods {
+			err := r.resolveFunction(method, FunctionTypeMethod)
+			if err != nil {
+				return err
+			}
+		}
+		for _, getter := range option.getters {
+			err := r.resolveFunction(getter, FunctionTypeMethod)
+			if err != nil {
+				return err
+			}
+		}
+		for _, setter := range option.setters {
+			err := r.resolveFunction(setter, FunctionTypeMethod)
 			if err != nil {
 				return err
 			}
 		}
 		r.endScope()
+		if option.superstruct != nil {
+			r.endScope()
+		}
+		r.currentStruct = enclosingStruct
+		for _, field := range option.staticFields {
+			if field.initializer == nil {
+				continue
+			}
+			err := r.resolveExpression(field.initializer)
+			if err != nil {
+				return err
+			}
+		}
 		return nil
 	case *ReturnStatement:
 		if option.value == nil {
 			return nil
 		}
+		if r.currentFunction == FunctionTypeInitializer {
+			return NewResolveError("Can't return a value from an initializer.")
+		}
 		err := r.resolveExpression(option.value)
 		if err != nil {
 			return err
 		}
+	case *TryStatement:
+		r.beginScope()
+		err, _ := r.resolve(option.body)
+		if err != nil {
+			return err
+		}
+		r.endScope()
+		if option.catchBody != nil {
+			r.beginScope()
+			if option.catchName != nil {
+				r.declare(option.catchName)
+				r.define(option.catchName)
+			}
+			catchErr, _ := r.resolve(option.catchBody)
+			if catchErr != nil {
+				return catchErr
+			}
+			r.endScope()
+		}
+		if option.finallyBody != nil {
+			r.beginScope()
+			finallyErr, _ := r.resolve(option.finallyBody)
+			if finallyErr != nil {
+				return finallyErr
+			}
+			r.endScope()
+		}
+	case *ThrowStatement:
+		return r.resolveExpression(option.value)
 	case *BreakStatement:
-		return nil
+		return r.resolveJump(option.keyword, option.label)
 	case *ContinueStatement:
-		return nil
+		return r.resolveJump(option.keyword, option.label)
 	case *BlockStatement:
 		r.beginScope()
 		err, _ := r.resolve(option.statements)
@@ -190,6 +570,7 @@ func (r *Resolver) resolveStatement(statement Statement) error {
 		}
 		r.endScope()
 	case *ForStatement:
+		r.beginScope()
 		initializerErr := r.resolveStatement(option.initializer)
 		if initializerErr != nil {
 			return initializerErr
@@ -202,16 +583,30 @@ func (r *Resolver) resolveStatement(statement Statement) error {
 		if incrementErr != nil {
 			return incrementErr
 		}
-		bodyErr := r.resolveStatement(option.body)
+		bodyErr := r.resolveLoop(option.label, option.body)
 		if bodyErr != nil {
 			return bodyErr
 		}
+		r.endScope()
+	case *ForInStatement:
+		iterableErr := r.resolveExpression(option.iterable)
+		if iterableErr != nil {
+			return iterableErr
+		}
+		r.beginScope()
+		r.declare(option.variable)
+		r.define(option.variable)
+		bodyErr := r.resolveLoop(option.label, option.body)
+		if bodyErr != nil {
+			return bodyErr
+		}
+		r.endScope()
 	case *WhileStatement:
 		conditionErr := r.resolveExpression(option.condition)
 		if conditionErr != nil {
 			return conditionErr
 		}
-		statementErr := r.resolveStatement(option.statement)
+		statementErr := r.resolveLoop(option.label, option.statement)
 		if statementErr != nil {
 			return statementErr
 		}
@@ -231,14 +626,26 @@ func (r *Resolver) resolveStatement(statement Statement) error {
 			}
 		}
 	case *LetStatement:
-		r.declare(option.name)
+		names := patternNames(option.pattern)
+		for index, name := range names {
+			if slices.ContainsFunc(names[:index], func(other *Token) bool { return other.lexeme == name.lexeme }) {
+				return NewResolveError("Duplicate binding '%v' in pattern.", name.lexeme)
+			}
+			r.declare(name)
+		}
 		if option.initializer != nil {
 			err := r.resolveExpression(option.initializer)
 			if err != nil {
 				return err
 			}
 		}
-		r.define(option.name)
+		for _, name := range names {
+			if option.constant {
+				r.defineConstant(name)
+			} else {
+				r.define(name)
+			}
+		}
 	case *PrintStatement:
 		err := r.resolveExpression(option.expression)
 		if err != nil {
@@ -2,9 +2,11 @@ package main
 
 import (
 	"fmt"
-	"regexp"
+	"math/big"
 	"strconv"
+	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	u "github.com/core/utils"
 )
@@ -26,23 +28,43 @@ const (
 	Star              = "star"
 	Colon             = "colon"
 	Question          = "question"
+	Percent           = "percent"
+	Caret             = "caret"
 
 	// One or two character tokens
-	Bang         = "bang"
-	BangEqual    = "bangEqual"
-	Equal        = "equal"
-	EqualEqual   = "equalEqual"
-	Greater      = "greater"
-	GreaterEqual = "greaterEqual"
-	Less         = "less"
-	LessEqual    = "lessEqual"
-	Or           = "or"
-	And          = "and"
+	Bang           = "bang"
+	BangEqual      = "bangEqual"
+	Equal          = "equal"
+	EqualEqual     = "equalEqual"
+	Greater        = "greater"
+	GreaterEqual   = "greaterEqual"
+	Less           = "less"
+	LessEqual      = "lessEqual"
+	Or             = "or"
+	And            = "and"
+	Ampersand      = "ampersand"
+	Pipe           = "pipe"
+	Tilde          = "tilde"
+	TildeSlash     = "tildeSlash"
+	StarStar       = "starStar"
+	LessLess       = "lessLess"
+	GreaterGreater = "greaterGreater"
+	PlusEqual      = "plusEqual"
+	MinusEqual     = "minusEqual"
+	StarEqual      = "starEqual"
+	SlashEqual     = "slashEqual"
+	PlusPlus       = "plusPlus"
+	MinusMinus     = "minusMinus"
+	FatArrow       = "fatArrow"
+	DotDot         = "dotDot"
+	DotDotEqual    = "dotDotEqual"
+	DotDotDot      = "dotDotDot"
 
 	// Literals
-	Identifier = "identifier"
-	String     = "string"
-	Number     = "number"
+	Identifier    = "identifier"
+	String        = "string"
+	Interpolation = "interpolation"
+	Number        = "number"
 
 	// Keywords
 
@@ -63,6 +85,14 @@ const (
 	Eof      = "eof"
 	Break    = "break"
 	Continue = "continue"
+	Static   = "static"
+	Match    = "match"
+	In       = "in"
+	Const    = "const"
+	Try      = "try"
+	Catch    = "catch"
+	Finally  = "finally"
+	Throw    = "throw"
 
 	// Special
 	AnonymusFunction = "AnonymusFunction"
@@ -85,6 +115,14 @@ var keywords = map[string]string{
 	"while":    While,
 	"break":    Break,
 	"continue": Continue,
+	"static":   Static,
+	"match":    Match,
+	"in":       In,
+	"const":    Const,
+	"try":      Try,
+	"catch":    Catch,
+	"finally":  Finally,
+	"throw":    Throw,
 }
 
 type Token struct {
@@ -92,40 +130,61 @@ type Token struct {
 	lexeme    string
 	literal   any
 	line      uint
+	column    uint
+	// Text of the "///" doc comments written right before a declaration, see
+	// declarations. The parser moves it onto the declared name.
+	trivia []string
 }
 
-func NewToken(tokenType string, lexeme string, literal any, line uint) *Token {
+func NewToken(tokenType string, lexeme string, literal any, line uint, column uint) *Token {
 	return &Token{
 		tokenType,
 		lexeme,
 		literal,
 		line,
+		column,
+		nil,
 	}
 }
 
 func (t Token) ToString() string {
-	return fmt.Sprintf("%s %d", t.tokenType, t.line)
+	return fmt.Sprintf("%s %d:%d", t.tokenType, t.line, t.column)
 }
 
 func NewScannerError(message string) error {
 	return u.NewError(" SyntaxError: %s", message)
 }
 
+// Scanner walks the source rune by rune, so every position below, columns
+// included, counts runes rather than bytes.
 type Scanner struct {
-	source  string
-	start   uint
-	current uint
-	line    uint
-	tokens  []*Token
+	source    []rune
+	start     uint
+	current   uint
+	line      uint
+	lineStart uint
+	// Line and column where the current token starts.
+	startLine uint
+	column    uint
+	tokens    []*Token
+	// Brace depth of every "${" the scanner is currently inside of.
+	interpolations []int
+	// Doc comments waiting to be attached to the next token.
+	docComments []string
 }
 
 func NewScanner(source string) *Scanner {
 	return &Scanner{
-		source,
+		[]rune(source),
+		0,
 		0,
+		1,
 		0,
 		1,
+		1,
 		[]*Token{},
+		[]int{},
+		nil,
 	}
 }
 
@@ -134,17 +193,26 @@ func (s Scanner) isAtEnd() bool {
 }
 
 func (s *Scanner) addToken(tokenType string, literal any) {
-	text := s.source[s.start:s.current]
-	token := NewToken(tokenType, text, literal, s.line)
+	text := string(s.source[s.start:s.current])
+	token := NewToken(tokenType, text, literal, s.line, s.column)
+	if declarations[tokenType] {
+		token.trivia = s.docComments
+	}
+	s.docComments = nil
 	s.tokens = append(s.tokens, token)
 }
 
-func (s *Scanner) advance() byte {
+func (s *Scanner) advance() rune {
 	s.current += 1
 	return s.source[s.current-1]
 }
 
-func (s *Scanner) match(expected byte) bool {
+func (s *Scanner) newLine() {
+	s.line++
+	s.lineStart = s.current
+}
+
+func (s *Scanner) match(expected rune) bool {
 	if s.isAtEnd() {
 		return false
 	}
@@ -155,16 +223,16 @@ func (s *Scanner) match(expected byte) bool {
 	return true
 }
 
-func (s Scanner) peek() byte {
+func (s Scanner) peek() rune {
 	if s.isAtEnd() {
-		return byte(0)
+		return rune(0)
 	}
 	return s.source[s.current]
 }
 
-func (s Scanner) peekNext() byte {
+func (s Scanner) peekNext() rune {
 	if s.current+1 >= uint(len(s.source)) {
-		return byte(0)
+		return rune(0)
 	}
 	return s.source[s.current+1]
 }
@@ -174,62 +242,317 @@ func (s Scanner) extractCurrentSlice() string {
 	sourceLen := uint(len(s.source))
 	start := u.Ternary(s.current < sliceLen, 0, s.current-sliceLen)
 	end := u.Ternary(start+sliceLen*2 > sourceLen, sourceLen, start+sliceLen*2)
-	return s.source[start:end]
+	return string(s.source[start:end])
 }
 
 func (s *Scanner) string() error {
+	var value strings.Builder
 	for s.peek() != '"' && !s.isAtEnd() {
-		if s.peek() == '\n' {
-			s.line++
+		c := s.advance()
+		if c == '\n' {
+			s.newLine()
 		}
-		s.advance()
+		if c == '\\' {
+			err := s.escape(&value)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if c == '$' && s.match('{') {
+			s.addToken(Interpolation, value.String())
+			s.interpolations = append(s.interpolations, 0)
+			return nil
+		}
+		value.WriteRune(c)
 	}
 	if s.isAtEnd() {
-		return NewScannerError("Unterminated string")
+		return s.stringError("Missing closing '\"'", "")
 	}
 	s.advance()
-	value := s.source[s.start+1 : s.current-1]
+	s.addToken(String, value.String())
+	return nil
+}
+
+func (s *Scanner) escape(value *strings.Builder) error {
+	if s.isAtEnd() {
+		return s.stringError("Missing closing '\"'", "")
+	}
+	c := s.advance()
+	switch c {
+	case 'n':
+		value.WriteByte('\n')
+	case 't':
+		value.WriteByte('\t')
+	case 'r':
+		value.WriteByte('\r')
+	case '0':
+		value.WriteByte(0)
+	case '\\', '"', '\'', '$':
+		value.WriteRune(c)
+	case 'u':
+		return s.unicodeEscape(value)
+	default:
+		return s.stringError("Invalid escape sequence", "\\"+string(c))
+	}
+	return nil
+}
+
+// unicodeEscape reads either four hex digits (\u00e9) or up to six hex digits
+// in braces (\u{1F600}) and writes the encoded code point.
+func (s *Scanner) unicodeEscape(value *strings.Builder) error {
+	digits := ""
+	braced := s.match('{')
+	maxDigits := u.Ternary(braced, 6, 4)
+	for len(digits) < maxDigits && s.isHexDigit(s.peek()) {
+		digits += string(s.advance())
+	}
+	escape := "\\u" + u.Ternary(braced, "{", "") + digits
+	if braced && !s.match('}') {
+		return s.stringError("Expected '}' after unicode escape", escape)
+	}
+	codePoint, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil || (!braced && len(digits) != 4) || !utf8.ValidRune(rune(codePoint)) {
+		return s.stringError("Invalid unicode escape", escape+u.Ternary(braced, "}", ""))
+	}
+	value.WriteRune(rune(codePoint))
+	return nil
+}
+
+func (s Scanner) isHexDigit(c rune) bool {
+	return strings.ContainsRune("0123456789abcdefABCDEF", c)
+}
+
+func (s *Scanner) rawString() error {
+	for s.peek() != '`' && !s.isAtEnd() {
+		if s.advance() == '\n' {
+			s.newLine()
+		}
+	}
+	if s.isAtEnd() {
+		return s.stringError("Missing closing '`'", "")
+	}
+	s.advance()
+	value := string(s.source[s.start+1 : s.current-1])
 	s.addToken(String, value)
 	return nil
 }
 
-func (s *Scanner) isDigit(c byte) bool {
-	return unicode.IsDigit(rune(c))
+// blockComment skips a "/* ... */" comment, which may contain nested ones.
+func (s *Scanner) blockComment() error {
+	depth := 1
+	for depth > 0 {
+		if s.isAtEnd() {
+			return NewScannerError("Unterminated block comment")
+		}
+		c := s.advance()
+		if c == '\n' {
+			s.newLine()
+		} else if c == '/' && s.match('*') {
+			depth++
+		} else if c == '*' && s.match('/') {
+			depth--
+		}
+	}
+	return nil
 }
 
-func (s *Scanner) number() error {
-	for s.isDigit(s.peek()) {
-		s.advance()
+func (s *Scanner) isDigit(c rune) bool {
+	return c >= '0' && c <= '9'
+}
+
+// declarations are the tokens starting a declaration, the only ones doc
+// comments attach to, plus identifiers, which start accessor members. Doc
+// comments before any other token are dropped.
+var declarations = map[string]bool{
+	Identifier: true,
+	Struct:     true,
+	Fn:         true,
+	Let:        true,
+	Const:      true,
+	Static:     true,
+}
+
+var numberBases = map[rune]int{
+	'x': 16,
+	'X': 16,
+	'o': 8,
+	'O': 8,
+	'b': 2,
+	'B': 2,
+}
+
+func (s Scanner) isBaseDigit(c rune, base int) bool {
+	switch base {
+	case 16:
+		return s.isHexDigit(c)
+	case 8:
+		return c >= '0' && c <= '7'
+	case 2:
+		return c == '0' || c == '1'
 	}
-	if s.peek() == '.' && s.isDigit(s.peekNext()) {
+	return s.isDigit(c)
+}
+
+// literalError reports a malformed literal with the offending text and the
+// position where the literal starts.
+func (s Scanner) literalError(message string, kind string, text string) error {
+	return NewScannerError(fmt.Sprintf("%s in %s literal '%s' at %d:%d", message, kind, text, s.startLine, s.column))
+}
+
+func (s Scanner) numberError(message string) error {
+	return s.literalError(message, "number", string(s.source[s.start:s.current]))
+}
+
+// stringError reports a malformed string, showing the first line of it as the
+// offending text unless a more precise one is given.
+func (s Scanner) stringError(message string, text string) error {
+	if text == "" {
+		text, _, _ = strings.Cut(string(s.source[s.start:s.current]), "\n")
+	}
+	return s.literalError(message, "string", text)
+}
+
+// digits consumes a run of digits of the given base appended to the ones already
+// read. Single underscores may separate digits and are dropped from the result.
+func (s *Scanner) digits(base int, digits string) (error, string) {
+	underscore := false
+	for s.isBaseDigit(s.peek(), base) || s.peek() == '_' {
+		c := s.advance()
+		if c != '_' {
+			digits += string(c)
+			underscore = false
+			continue
+		}
+		if digits == "" {
+			return s.numberError("Unexpected leading '_'"), ""
+		}
+		if underscore {
+			return s.numberError("Consecutive '_'"), ""
+		}
+		underscore = true
+	}
+	if underscore {
+		return s.numberError("Trailing '_'"), ""
+	}
+	return nil, digits
+}
+
+// matchSuffix consumes a literal suffix such as the "n" of 10n, provided it is
+// not the start of a longer word.
+func (s *Scanner) matchSuffix(suffix rune) bool {
+	if s.peek() != suffix || s.isAlphaNumeric(s.peekNext()) {
+		return false
+	}
+	s.advance()
+	return true
+}
+
+// integer adds an integer literal token. Literals with the "n" suffix, and the
+// ones too large for int64, become big integers.
+func (s *Scanner) integer(digits string, base int, isBig bool) error {
+	if !isBig {
+		integer, err := strconv.ParseInt(digits, base, 64)
+		if err == nil {
+			s.addToken(Number, integer)
+			return nil
+		}
+	}
+	bigInteger, ok := new(big.Int).SetString(digits, base)
+	if !ok {
+		return s.numberError("Invalid digits")
+	}
+	s.addToken(Number, bigInteger)
+	return nil
+}
+
+func (s *Scanner) number() error {
+	first := s.source[s.start]
+	base, hasPrefix := numberBases[s.peek()]
+	if first == '0' && hasPrefix {
 		s.advance()
-		for s.isDigit(s.peek()) {
+		err, digits := s.digits(base, "")
+		if err != nil {
+			return err
+		}
+		isBig := s.matchSuffix('n')
+		if s.isAlphaNumeric(s.peek()) {
 			s.advance()
+			return s.numberError("Invalid digit")
+		}
+		if digits == "" {
+			return s.numberError("Expected digits")
 		}
+		return s.integer(digits, base, isBig)
 	}
-	value := s.source[s.start:s.current]
-	float, err := strconv.ParseFloat(value, 64)
+	err, value := s.digits(10, string(first))
 	if err != nil {
 		return err
 	}
+	isFloat := false
+	if s.peek() == '.' && s.isDigit(s.peekNext()) {
+		s.advance()
+		err, fraction := s.digits(10, "")
+		if err != nil {
+			return err
+		}
+		value += "." + fraction
+		isFloat = true
+	}
+	if s.peek() == 'e' || s.peek() == 'E' {
+		s.advance()
+		exponent := "e"
+		if s.peek() == '+' || s.peek() == '-' {
+			exponent += string(s.advance())
+		}
+		if !s.isDigit(s.peek()) {
+			return s.numberError("Expected exponent digits")
+		}
+		err, digits := s.digits(10, "")
+		if err != nil {
+			return err
+		}
+		value += exponent + digits
+		isFloat = true
+	}
+	isBig := !isFloat && s.matchSuffix('n')
+	isDecimal := !isBig && s.matchSuffix('m')
+	if s.isAlphaNumeric(s.peek()) {
+		s.advance()
+		return s.numberError("Unexpected character")
+	}
+	if isDecimal {
+		if strings.ContainsAny(value, "eE") {
+			return s.numberError("Exponent not allowed")
+		}
+		_, decimal := ParseGSDecimal(value)
+		s.addToken(Number, decimal)
+		return nil
+	}
+	if !isFloat {
+		return s.integer(value, 10, isBig)
+	}
+	float, parseErr := strconv.ParseFloat(value, 64)
+	if parseErr != nil {
+		return s.numberError("Value out of range")
+	}
 	s.addToken(Number, float)
 	return nil
 }
 
-func (s Scanner) isAlpha(char byte) bool {
-	re := regexp.MustCompile("[A-Za-z_]")
-	return re.MatchString(string(char))
+func (s Scanner) isAlpha(char rune) bool {
+	return char == '_' || unicode.IsLetter(char)
 }
 
-func (s Scanner) isAlphaNumeric(char byte) bool {
-	return s.isAlpha(char) || s.isDigit(char)
+func (s Scanner) isAlphaNumeric(char rune) bool {
+	return s.isAlpha(char) || unicode.IsDigit(char)
 }
 
 func (s *Scanner) identifier() {
 	for s.isAlphaNumeric(s.peek()) {
 		s.advance()
 	}
-	text := s.source[s.start:s.current]
+	text := string(s.source[s.start:s.current])
 	tokenType, exists := keywords[text]
 	if !exists {
 		tokenType = Identifier
@@ -241,24 +564,33 @@ func (s *Scanner) scanToken() error {
 	c := s.advance()
 	switch c {
 	case '&':
-		if s.match('&') {
-			s.addToken(And, "")
-		} else {
-			return u.NewError("Unterminted &")
-		}
+		s.addToken(u.Ternary(s.match('&'), And, Ampersand), "")
 	case '|':
-		if s.match('|') {
-			s.addToken(Or, "")
-		} else {
-			return u.NewError("Unterminted |")
-		}
+		s.addToken(u.Ternary(s.match('|'), Or, Pipe), "")
+	case '^':
+		s.addToken(Caret, "")
+	case '~':
+		s.addToken(u.Ternary(s.match('/'), TildeSlash, Tilde), "")
+	case '%':
+		s.addToken(Percent, "")
 	case '(':
 		s.addToken(LeftBrace, "")
 	case ')':
 		s.addToken(RightBrace, "")
 	case '{':
+		if len(s.interpolations) > 0 {
+			s.interpolations[len(s.interpolations)-1]++
+		}
 		s.addToken(LeftCurlyBracket, "")
 	case '}':
+		if len(s.interpolations) > 0 {
+			top := len(s.interpolations) - 1
+			if s.interpolations[top] == 0 {
+				s.interpolations = s.interpolations[:top]
+				return s.string()
+			}
+			s.interpolations[top]--
+		}
 		s.addToken(RightCurlyBracket, "")
 	case '[':
 		s.addToken(LeftBracket, "")
@@ -267,15 +599,35 @@ func (s *Scanner) scanToken() error {
 	case ',':
 		s.addToken(Comma, "")
 	case '.':
-		s.addToken(Dot, "")
+		if s.match('.') {
+			if s.match('.') {
+				s.addToken(DotDotDot, "")
+			} else {
+				s.addToken(u.Ternary(s.match('='), DotDotEqual, DotDot), "")
+			}
+		} else {
+			s.addToken(Dot, "")
+		}
 	case '-':
-		s.addToken(Minus, "")
+		if s.match('-') {
+			s.addToken(MinusMinus, "")
+		} else {
+			s.addToken(u.Ternary(s.match('='), MinusEqual, Minus), "")
+		}
 	case '+':
-		s.addToken(Plus, "")
+		if s.match('+') {
+			s.addToken(PlusPlus, "")
+		} else {
+			s.addToken(u.Ternary(s.match('='), PlusEqual, Plus), "")
+		}
 	case ';':
 		s.addToken(Semicolon, "")
 	case '*':
-		s.addToken(Star, "")
+		if s.match('*') {
+			s.addToken(StarStar, "")
+		} else {
+			s.addToken(u.Ternary(s.match('='), StarEqual, Star), "")
+		}
 	case '?':
 		s.addToken(Question, "")
 	case ':':
@@ -283,33 +635,54 @@ func (s *Scanner) scanToken() error {
 	case '!':
 		s.addToken(u.Ternary(s.match('='), BangEqual, Bang), "")
 	case '=':
-		s.addToken(u.Ternary(s.match('='), EqualEqual, Equal), "")
+		if s.match('>') {
+			s.addToken(FatArrow, "")
+		} else {
+			s.addToken(u.Ternary(s.match('='), EqualEqual, Equal), "")
+		}
 	case '<':
-		s.addToken(u.Ternary(s.match('='), LessEqual, Less), "")
+		if s.match('<') {
+			s.addToken(LessLess, "")
+		} else {
+			s.addToken(u.Ternary(s.match('='), LessEqual, Less), "")
+		}
 	case '>':
-		s.addToken(u.Ternary(s.match('='), GreaterEqual, Greater), "")
+		if s.match('>') {
+			s.addToken(GreaterGreater, "")
+		} else {
+			s.addToken(u.Ternary(s.match('='), GreaterEqual, Greater), "")
+		}
 	case '/':
 		if s.match('/') {
+			isDoc := s.peek() == '/' && s.peekNext() != '/'
 			for s.peek() != '\n' && !s.isAtEnd() {
 				s.advance()
 			}
+			if isDoc {
+				comment := strings.TrimSpace(string(s.source[s.start+3 : s.current]))
+				s.docComments = append(s.docComments, comment)
+			}
+		} else if s.match('*') {
+			return s.blockComment()
 		} else {
-			s.addToken(Slash, "")
+			s.addToken(u.Ternary(s.match('='), SlashEqual, Slash), "")
 		}
 	case ' ':
 	case '\r':
 	case '\t':
 	case '\n':
-		s.line++
+		s.newLine()
 	case '"':
-		s.string()
+		return s.string()
+	case '`':
+		return s.rawString()
 	default:
 		if s.isDigit(c) {
 			return s.number()
 		} else if s.isAlpha(c) {
 			s.identifier()
 		} else {
-			return NewScannerError(fmt.Sprintf("Unexpected character \"%s\"", string(c)))
+			return NewScannerError(fmt.Sprintf("Unexpected character \"%s\" at %d:%d", string(c), s.line, s.column))
 		}
 	}
 	return nil
@@ -318,12 +691,17 @@ func (s *Scanner) scanToken() error {
 func (s *Scanner) scanTokens() (error, []*Token) {
 	for !s.isAtEnd() {
 		s.start = s.current
+		s.startLine = s.line
+		s.column = s.current - s.lineStart + 1
 		err := s.scanToken()
 		if err != nil {
 			return err, s.tokens
 		}
 	}
-	token := NewToken(Eof, "Eof", "", s.line)
+	if len(s.interpolations) > 0 {
+		return NewScannerError("Unterminated string interpolation"), s.tokens
+	}
+	token := NewToken(Eof, "Eof", "", s.line, s.current-s.lineStart+1)
 	s.tokens = append(s.tokens, token)
 	return nil, s.tokens
 }
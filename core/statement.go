@@ -23,14 +23,16 @@ func NewExpressionStatement(expression Expression) *ExpressionStatement {
 }
 
 type LetStatement struct {
-	name        *Token
+	pattern     Pattern
 	initializer Expression
+	constant    bool
 }
 
-func NewLetStatement(name *Token, initializer Expression) *LetStatement {
+func NewLetStatement(pattern Pattern, initializer Expression, constant bool) *LetStatement {
 	return &LetStatement{
-		name,
+		pattern,
 		initializer,
+		constant,
 	}
 }
 
@@ -69,29 +71,34 @@ func NewIfStatement(condition Expression,
 }
 
 type WhileStatement struct {
+	label     *Token
 	condition Expression
 	statement Statement
 }
 
-func NewWhileStatement(condition Expression, statement Statement) *WhileStatement {
+func NewWhileStatement(label *Token, condition Expression, statement Statement) *WhileStatement {
 	return &WhileStatement{
+		label,
 		condition,
 		statement,
 	}
 }
 
 type ForStatement struct {
+	label       *Token
 	condition   Expression
 	initializer Statement
 	increment   Expression
 	body        Statement
 }
 
-func NewForStatement(condition Expression,
+func NewForStatement(label *Token,
+	condition Expression,
 	initializer Statement,
 	increment Expression,
 	body Statement) *ForStatement {
 	return &ForStatement{
+		label,
 		condition,
 		initializer,
 		increment,
@@ -99,16 +106,74 @@ func NewForStatement(condition Expression,
 	}
 }
 
-type BreakStatement struct{}
+type ForInStatement struct {
+	label    *Token
+	variable *Token
+	iterable Expression
+	body     Statement
+}
 
-func NewBreakStatement() *BreakStatement {
-	return &BreakStatement{}
+func NewForInStatement(label *Token, variable *Token, iterable Expression, body Statement) *ForInStatement {
+	return &ForInStatement{
+		label,
+		variable,
+		iterable,
+		body,
+	}
 }
 
-type ContinueStatement struct{}
+type BreakStatement struct {
+	keyword *Token
+	label   *Token
+}
+
+func NewBreakStatement(keyword *Token, label *Token) *BreakStatement {
+	return &BreakStatement{
+		keyword,
+		label,
+	}
+}
+
+type ContinueStatement struct {
+	keyword *Token
+	label   *Token
+}
+
+func NewContinueStatement(keyword *Token, label *Token) *ContinueStatement {
+	return &ContinueStatement{
+		keyword,
+		label,
+	}
+}
 
-func NewContinueStatement() *ContinueStatement {
-	return &ContinueStatement{}
+// TryStatement has a nil catchBody when there is no catch clause, and a nil
+// catchName when the clause doesn't bind the error.
+type TryStatement struct {
+	body        []Statement
+	catchName   *Token
+	catchBody   []Statement
+	finallyBody []Statement
+}
+
+func NewTryStatement(body []Statement, catchName *Token, catchBody []Statement, finallyBody []Statement) *TryStatement {
+	return &TryStatement{
+		body,
+		catchName,
+		catchBody,
+		finallyBody,
+	}
+}
+
+type ThrowStatement struct {
+	keyword *Token
+	value   Expression
+}
+
+func NewThrowStatement(keyword *Token, value Expression) *ThrowStatement {
+	return &ThrowStatement{
+		keyword,
+		value,
+	}
 }
 
 type ReturnStatement struct {
@@ -122,13 +187,29 @@ func NewReturnStatement(value Expression) *ReturnStatement {
 }
 
 type StructStatment struct {
-	name    *Token
-	methods []*Function
-}
-
-func NewStructStatment(name *Token, methods []*Function) *StructStatment {
+	name          *Token
+	superstruct   *Variable
+	methods       []*Function
+	staticMethods []*Function
+	staticFields  []*LetStatement
+	getters       []*Function
+	setters       []*Function
+}
+
+func NewStructStatment(name *Token,
+	superstruct *Variable,
+	methods []*Function,
+	staticMethods []*Function,
+	staticFields []*LetStatement,
+	getters []*Function,
+	setters []*Function) *StructStatment {
 	return &StructStatment{
 		name,
+		superstruct,
 		methods,
+		staticMethods,
+		staticFields,
+		getters,
+		setters,
 	}
 }
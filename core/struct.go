@@ -1,14 +1,35 @@
 package main
 
+const (
+	InitMethod  = "init"
+	SetAccessor = "set"
+)
+
 type GSStruct struct {
-	name    string
-	methods map[string]*GSFunction
+	name          string
+	superstruct   *GSStruct
+	methods       map[string]*GSFunction
+	staticMethods map[string]*GSFunction
+	getters       map[string]*GSFunction
+	setters       map[string]*GSFunction
+	fields        map[string]any
 }
 
-func NewGSStruct(name string, methods map[string]*GSFunction) *GSStruct {
+func NewGSStruct(name string,
+	superstruct *GSStruct,
+	methods map[string]*GSFunction,
+	staticMethods map[string]*GSFunction,
+	getters map[string]*GSFunction,
+	setters map[string]*GSFunction) *GSStruct {
+	fields := map[string]any{}
 	return &GSStruct{
 		name,
+		superstruct,
 		methods,
+		staticMethods,
+		getters,
+		setters,
+		fields,
 	}
 }
 
@@ -16,23 +37,114 @@ func (g GSStruct) String() string {
 	return "[struct: " + g.name + "]"
 }
 
-func (g GSStruct) arity() int {
-	return 0
+func (g *GSStruct) minArity() int {
+	initializer := g.findMethod(InitMethod)
+	if initializer == nil {
+		return 0
+	}
+	return initializer.minArity()
+}
+
+func (g *GSStruct) maxArity() int {
+	initializer := g.findMethod(InitMethod)
+	if initializer == nil {
+		return 0
+	}
+	return initializer.maxArity()
+}
+
+func (g *GSStruct) parameters() []*Token {
+	initializer := g.findMethod(InitMethod)
+	if initializer == nil {
+		return []*Token{}
+	}
+	return initializer.parameters()
 }
 
-func (g GSStruct) call(i *Interpreter, arguments []any) (error, any) {
-	instance := NewGSInstance(&g)
+func (g *GSStruct) call(i *Interpreter, arguments []any) (error, any) {
+	instance := NewGSInstance(g)
+	initializer := g.findMethod(InitMethod)
+	if initializer != nil {
+		err, _ := initializer.bind(instance).call(i, arguments)
+		if err != nil {
+			return err, nil
+		}
+	}
 	return nil, instance
 }
 
-func (g GSStruct) findMethod(name string) *GSFunction {
+func (g *GSStruct) findMethod(name string) *GSFunction {
 	method, ok := g.methods[name]
 	if ok {
 		return method
 	}
+	if g.superstruct != nil {
+		return g.superstruct.findMethod(name)
+	}
 	return nil
 }
 
+func (g *GSStruct) findStaticMethod(name string) *GSFunction {
+	method, ok := g.staticMethods[name]
+	if ok {
+		return method
+	}
+	if g.superstruct != nil {
+		return g.superstruct.findStaticMethod(name)
+	}
+	return nil
+}
+
+func (g *GSStruct) findGetter(name string) *GSFunction {
+	getter, ok := g.getters[name]
+	if ok {
+		return getter
+	}
+	if g.superstruct != nil {
+		return g.superstruct.findGetter(name)
+	}
+	return nil
+}
+
+func (g *GSStruct) findSetter(name string) *GSFunction {
+	setter, ok := g.setters[name]
+	if ok {
+		return setter
+	}
+	if g.superstruct != nil {
+		return g.superstruct.findSetter(name)
+	}
+	return nil
+}
+
+func (g *GSStruct) inherits(other *GSStruct) bool {
+	for gsStruct := g; gsStruct != nil; gsStruct = gsStruct.superstruct {
+		if gsStruct == other {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *GSStruct) get(name *Token) (error, any) {
+	value, ok := g.fields[name.lexeme]
+	if ok {
+		return nil, value
+	}
+	method := g.findStaticMethod(name.lexeme)
+	if method != nil {
+		return nil, method.bind(g)
+	}
+	if g.superstruct != nil {
+		return g.superstruct.get(name)
+	}
+	return NewRuntimeError("Undefined property '" + name.lexeme + "'"), nil
+}
+
+func (g *GSStruct) set(name *Token, value any) {
+	g.fields[name.lexeme] = value
+}
+
 type GSInstance struct {
 	gsStruct *GSStruct
 	fields   map[string]any
@@ -50,18 +162,36 @@ func (g GSInstance) String() string {
 	return "[instance: " + g.gsStruct.name + "]"
 }
 
-func (g GSInstance) get(name *Token) (error, any) {
+func (g *GSInstance) get(i *Interpreter, name *Token) (error, any) {
+	getter := g.gsStruct.findGetter(name.lexeme)
+	if getter != nil {
+		return getter.bind(g).call(i, []any{})
+	}
 	value, ok := g.fields[name.lexeme]
 	if ok {
 		return nil, value
 	}
 	method := g.gsStruct.findMethod(name.lexeme)
 	if method != nil {
-		return nil, method.bind(&g)
+		return nil, method.bind(g)
 	}
 	return NewRuntimeError("Undefined property '" + name.lexeme + "'"), nil
 }
 
-func (g GSInstance) set(name *Token, value any) {
+func (g *GSInstance) hasProperty(name string) bool {
+	_, ok := g.fields[name]
+	return ok || g.gsStruct.findGetter(name) != nil
+}
+
+func (g *GSInstance) set(i *Interpreter, name *Token, value any) error {
+	setter := g.gsStruct.findSetter(name.lexeme)
+	if setter != nil {
+		err, _ := setter.bind(g).call(i, []any{value})
+		return err
+	}
+	if g.gsStruct.findGetter(name.lexeme) != nil {
+		return NewRuntimeError("Can't set read-only property '" + name.lexeme + "'")
+	}
 	g.fields[name.lexeme] = value
+	return nil
 }
@@ -0,0 +1,40 @@
+struct Rectangle {
+  fn init(w, h) {
+    this.w = w;
+    this.h = h;
+  }
+
+  area {
+    return this.w * this.h;
+  }
+
+  side {
+    return this.w;
+  }
+
+  set side(value) {
+    this.w = value;
+    this.h = value;
+  }
+}
+
+let r = Rectangle(2, 3);
+print r.area;
+r.w = 4;
+print r.area;
+r.side = 5;
+print r.side;
+print r.area;
+
+struct Square < Rectangle {
+  fn init(side) {
+    super.init(side, side);
+  }
+}
+
+let s = Square(3);
+print s.area;
+s.side = 2;
+print s.area;
+
+r.area = 1;
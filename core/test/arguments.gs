@@ -0,0 +1,46 @@
+fn connect(host, port = 8080, secure = port == 443) {
+    return "${host}:${port} secure=${secure}";
+}
+
+print connect("localhost");
+print connect("localhost", 9000);
+print connect("example.com", port: 443);
+print connect(host: "example.com", secure: true);
+print connect("example.com", secure: false, port: 443);
+
+let calls = 0;
+fn counter() {
+    calls += 1;
+    return calls;
+}
+fn tick(value = counter()) {
+    return value;
+}
+print tick();
+print tick();
+print tick(10);
+print calls;
+
+struct Vector {
+    fn init(x = 0, y = 0) {
+        this.x = x;
+        this.y = y;
+    }
+
+    fn scaled(factor = 2) {
+        return Vector(this.x * factor, y: this.y * factor);
+    }
+}
+
+let v = Vector(y: 3);
+print "${v.x}, ${v.y}";
+let w = v.scaled();
+print "${w.x}, ${w.y}";
+let z = Vector(1).scaled(factor: 10);
+print "${z.x}, ${z.y}";
+
+let greet = fn (name, greeting = "Hello") { return "${greeting}, ${name}!"; };
+print greet("Ada");
+print greet(greeting: "Hi", name: "Grace");
+
+print connect();
@@ -0,0 +1,17 @@
+let xs = [1, 2, 3];
+print xs;
+print xs[0];
+print len(xs);
+
+xs[1] = "two";
+print xs;
+
+let matrix = [[1, 2], [3, 4]];
+print matrix[1][0];
+matrix[0][1] = 5;
+print matrix;
+
+print len([]);
+print len("abc");
+
+print xs[3];
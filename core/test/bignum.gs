@@ -0,0 +1,94 @@
+print 9223372036854775807 + 1;
+print 2 ** 100;
+print 123n;
+print 0xFFn * 2;
+print 100000000000000000000;
+print 9223372036854775807 * 3 - 9223372036854775807 * 3;
+print (2 ** 64) / 3;
+print -(2 ** 64) ~/ 5;
+print (2 ** 64) % 7;
+print (2 ** 64) > 1;
+print 2 ** 64 == 18446744073709551616n;
+print 1n == 1;
+print 2 ** 64 + 0.5;
+
+let price = 19.99m;
+let quantity = 3;
+print price * quantity;
+print 0.1m + 0.2m;
+print 0.1m + 0.2m == 0.3m;
+print 10.00m / 4;
+print 1m / 3;
+print decimal("2.675");
+print round(decimal("2.675"), 2);
+decimalContext(4, "halfUp");
+print 2m / 3;
+print round(2.345m, 2);
+decimalContext(4, "down");
+print 2m / 3;
+print round(2.345m, 2);
+print -7.5m ~/ 2;
+print 7.5m % 2;
+print 1.5m ** 2;
+print 0 << 100000000000000;
+print 1 << 1000 >> 998;
+
+try {
+    print (2 ** 64) << 100000000000000000;
+} catch (e) {
+    print e.message;
+}
+
+try {
+    print 1 << 100000000000000;
+} catch (e) {
+    print e.message;
+}
+
+try {
+    print 3 ** 100000000000000;
+} catch (e) {
+    print e.message;
+}
+
+try {
+    print 0.5m ** 100000000000;
+} catch (e) {
+    print e.message;
+}
+
+print 1 ** 100000000000000;
+print (-1) ** 100000000000001;
+
+try {
+    print round(decimal("1.55"), 4294967297);
+} catch (e) {
+    print e.message;
+}
+
+try {
+    print round(decimal("1.5"), 200000000);
+} catch (e) {
+    print e.message;
+}
+
+try {
+    decimalContext(3000000000, "halfEven");
+} catch (e) {
+    print e.message;
+}
+
+try {
+    print 0.1m ** 100000;
+} catch (e) {
+    print e.message;
+}
+
+print round(decimal("1.55"), -1);
+print round(15, -1);
+print round(15m, -1);
+print round(2 ** 70, -20);
+print 1.5m > 1;
+print 1.5m < 2.0;
+print decimal(0.1) + 0.2m;
+print 1.5m + 1.5;
@@ -0,0 +1,6 @@
+struct Handler {}
+
+let handler = Handler();
+handler.run = fn () { break; };
+print "never";
+handler.run();
@@ -0,0 +1,59 @@
+/// Adds two numbers.
+/// Used by the block below.
+fn add(a, b) {
+  return a + b; // trailing line comment
+}
+
+/* a block comment
+   spanning lines */
+print add(1, 2);
+
+/*
+print "disabled";
+/* nested */
+print "still disabled";
+*/
+
+print /* inline */ add(3, 4);
+//// not a doc comment
+print 10 /* comment */ / 2;
+
+print doc(add);
+
+struct Temperature {
+  fn init(celsius) {
+    this.celsius = celsius;
+  }
+
+  /// Degrees Fahrenheit.
+  fahrenheit {
+    return this.celsius * 9 / 5 + 32;
+  }
+
+  /// Sets the temperature
+  /// from degrees Fahrenheit.
+  set fahrenheit(value) {
+    this.celsius = (value - 32) * 5 / 9;
+  }
+
+  /// Degrees Kelvin.
+  set kelvin(value) {
+    this.celsius = value - 273.15;
+  }
+
+  /// The freezing point of water.
+  static fn freezing() {
+    return Temperature(0);
+  }
+
+  fn undocumented() {
+    return null;
+  }
+}
+
+print doc(Temperature, "fahrenheit");
+print doc(Temperature, "kelvin");
+print doc(Temperature, "freezing");
+print doc(Temperature.freezing);
+print doc(Temperature, "undocumented");
+print doc(fn () {});
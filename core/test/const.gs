@@ -0,0 +1,27 @@
+const LIMIT = 10;
+const [LOW, HIGH] = [1, 5];
+print LIMIT;
+print LOW + HIGH;
+
+fn check(value) {
+    const MAX = LIMIT * 2;
+    if (value > MAX) {
+        return "too big";
+    }
+    {
+        let MAX = 0;
+        MAX = value;
+        return "ok ${MAX}";
+    }
+}
+print check(5);
+print check(50);
+
+const settings = {"debug": false};
+settings["debug"] = true;
+print settings;
+
+fn change() {
+    LIMIT = 20;
+}
+change();
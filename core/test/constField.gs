@@ -0,0 +1,9 @@
+struct Box {}
+
+fn fill(box) {
+    const SIZE = 1;
+    box.size = SIZE = 2;
+    return box.size;
+}
+print "never";
+print fill(Box());
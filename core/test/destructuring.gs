@@ -0,0 +1,62 @@
+let xs = [1, 2, 3, 4, 5];
+let [a, b, ...rest] = xs;
+print a;
+print b;
+print rest;
+
+let [first, [inner, ...others]] = ["x", ["y", "z", "w"]];
+print "${first} ${inner} ${others}";
+
+let [only, ...empty] = [1];
+print empty;
+
+let [c1, c2] = "hé";
+print c2;
+
+let [low, high] = 5..7;
+print high;
+
+struct Person {
+    fn init(name, age) {
+        this.name = name;
+        this.age = age;
+    }
+
+    title {
+        return "Dr. ${this.name}";
+    }
+}
+
+let {name, age} = Person("Ada", 36);
+print "${name} is ${age}";
+
+let {title, name: fullName} = Person("Grace", 85);
+print title;
+print fullName;
+
+let {host, port: [major, minor]} = {"host": "localhost", "port": [80, 81]};
+print "${host} ${major} ${minor}";
+
+let p = 1;
+let q = 2;
+[p, q] = [q, p];
+print "${p} ${q}";
+
+let point = Person("P", 0);
+let scores = [0, 0, 0];
+[point.name, scores[1], ...rest] = ["renamed", 10, 20, 30];
+print point.name;
+print scores;
+print rest;
+
+for (let [i, j] = [0, 10]; i < 3; i += 1) {
+    print i + j;
+}
+
+fn pair() {
+    return ["left", "right"];
+}
+let [l, r] = pair();
+print "${l}-${r}";
+
+let [tooFew, missing] = [1];
@@ -0,0 +1,118 @@
+try {
+    print "before";
+    throw "boom";
+    print "never";
+} catch (e) {
+    print e;
+    print e.message;
+    print e.kind;
+    print e.line;
+    print e.value;
+}
+
+try {
+    print 1 / 0;
+} catch (e) {
+    print "${e.kind}: ${e.message} on line ${e.line}";
+}
+
+try {
+    len(1, 2);
+} catch (e) {
+    print e.message;
+}
+
+fn validate(age) {
+    if (age < 0) {
+        throw error("age can't be negative", "ValidationError");
+    }
+    return age;
+}
+
+try {
+    validate(-1);
+} catch (e) {
+    print "${e.kind}: ${e.message} (line ${e.line})";
+}
+
+fn withFinally() {
+    try {
+        return "returned";
+    } finally {
+        print "finally runs on return";
+    }
+}
+print withFinally();
+
+for (i in 0..5) {
+    try {
+        if (i == 1) {
+            continue;
+        }
+        if (i == 3) {
+            break;
+        }
+        print "body ${i}";
+    } finally {
+        print "finally ${i}";
+    }
+}
+
+fn rethrow() {
+    try {
+        throw error("inner");
+    } catch (e) {
+        throw error("outer after ${e.message}");
+    } finally {
+        print "cleanup";
+    }
+}
+try {
+    rethrow();
+} catch (e) {
+    print e.message;
+}
+
+try {
+    try {
+        throw 42;
+    } finally {
+        print "inner finally";
+    }
+} catch {
+    print "caught without a name";
+}
+
+let point = {"x": 1};
+try {
+    print point["y"];
+} catch (e) {
+    print e;
+}
+
+try {
+    print undefinedName;
+} catch (e) {
+    print "${e.kind} on line ${e.line}: ${e.message}";
+}
+
+try {
+    "text"();
+} catch (e) {
+    print "${e.kind} on line ${e.line}: ${e.message}";
+}
+
+let shared = error("shared");
+try {
+    throw shared;
+} catch (e) {
+    print "first throw on line ${e.line}";
+}
+try {
+    throw shared;
+} catch (e) {
+    print "second throw on line ${e.line}";
+}
+print shared.line;
+
+throw error("unhandled", "FatalError");
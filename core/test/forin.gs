@@ -0,0 +1,70 @@
+for (fruit in ["apple", "banana", "cherry"]) {
+    print fruit;
+}
+
+let ages = {"ann": 31, "bob": 42};
+for (name in ages) {
+    print "${name} is ${ages[name]}";
+}
+
+for (character in "héllo") {
+    if (character == "l") {
+        continue;
+    }
+    print character;
+}
+
+let total = 0;
+for (n in [1, 2, 3, 4, 5, 6]) {
+    if (n > 4) {
+        break;
+    }
+    total += n;
+}
+print total;
+
+let callbacks = [null, null, null];
+let index = 0;
+for (n in [1, 2, 3]) {
+    callbacks[index] = fn () { return n * 10; };
+    index += 1;
+}
+print callbacks[0]() + callbacks[1]() + callbacks[2]();
+
+struct Countdown {
+    fn init(from) {
+        this.from = from;
+    }
+
+    fn iter() {
+        return CountdownIterator(this.from);
+    }
+}
+
+struct CountdownIterator {
+    fn init(current) {
+        this.current = current;
+    }
+
+    fn next() {
+        if (this.current == 0) {
+            return null;
+        }
+        this.current -= 1;
+        return this.current + 1;
+    }
+}
+
+for (n in Countdown(3)) {
+    print n;
+}
+
+for (n in CountdownIterator(2)) {
+    for (m in ["a", "b"]) {
+        print "${n}${m}";
+    }
+}
+
+for (n in 5) {
+    print n;
+}
@@ -0,0 +1,65 @@
+let a = 1;
+a += 2;
+print a;
+a -= 1;
+print a;
+a *= 5;
+print a;
+a /= 4;
+print a;
+
+print a++;
+print a;
+print ++a;
+print a--;
+print --a;
+
+let s = "a";
+s += "b";
+print s;
+
+struct Counter {
+  fn init() {
+    this.count = 0;
+  }
+}
+
+let c = Counter();
+c.count += 10;
+c.count++;
+print c.count;
+
+let calls = 0;
+fn counter() {
+  calls++;
+  return c;
+}
+counter().count *= 2;
+print c.count;
+print calls;
+
+let xs = [1, 2, 3];
+let i = 0;
+xs[i++] += 10;
+print xs;
+print i;
+xs[2]--;
+print xs;
+
+let m = {"hits": 0};
+m["hits"]++;
+m["hits"] += 5;
+print m;
+
+for (let j = 0; j < 3; j++) {
+  print j;
+}
+
+fn loop() {
+  let total = 0;
+  for (let j = 1; j <= 4; j += 1) {
+    total += j;
+  }
+  return total;
+}
+print loop();
@@ -0,0 +1,38 @@
+struct Animal {
+  fn speak() {
+    print "...";
+  }
+
+  fn describe() {
+    print "I am an animal";
+  }
+}
+
+struct Dog < Animal {
+  fn speak() {
+    print "Woof";
+  }
+
+  fn describe() {
+    super.describe();
+    print "and a dog";
+  }
+}
+
+struct Puppy < Dog {
+  fn speak() {
+    super.speak();
+    print "(tiny)";
+  }
+}
+
+let dog = Dog();
+dog.speak();
+dog.describe();
+
+let puppy = Puppy();
+puppy.speak();
+puppy.describe();
+
+let describe = puppy.describe;
+describe();
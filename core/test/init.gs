@@ -0,0 +1,34 @@
+struct Point {
+  fn init(x, y) {
+    this.x = x;
+    this.y = y;
+  }
+
+  fn sum() {
+    return this.x + this.y;
+  }
+}
+
+let p = Point(1, 2);
+print p.x;
+print p.y;
+print p.sum();
+
+print p.init(3, 4) == p;
+print p.sum();
+
+struct Point3 < Point {
+  fn init(x, y, z) {
+    super.init(x, y);
+    this.z = z;
+    if (z == 0) return;
+    this.z = z * 10;
+  }
+}
+
+let q = Point3(1, 2, 3);
+print q.z;
+print q.sum();
+print Point3(1, 1, 0).z;
+
+Point(1);
@@ -0,0 +1,9 @@
+let name = "world";
+let count = 2;
+print "Hello ${name}, you have ${count + 1} items";
+print "${count}";
+print "nested ${"inner ${name}"} done";
+print "map ${{"a": 1}["a"]} and array ${[1, 2]}";
+print "escaped \${name}";
+print "fn ${len}";
+print `raw ${name}`;
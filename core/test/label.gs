@@ -0,0 +1,47 @@
+outer: for (i in 0..3) {
+    for (j in 0..3) {
+        if (j == 2) {
+            continue outer;
+        }
+        if (i == 2) {
+            break outer;
+        }
+        print "${i} ${j}";
+    }
+}
+
+let found = null;
+search: for (let row = 0; row < 3; row = row + 1) {
+    let col = 0;
+    while (col < 3) {
+        if (row * col == 2) {
+            found = [row, col];
+            break search;
+        }
+        col += 1;
+    }
+}
+print found;
+
+let n = 0;
+let skipped = 0;
+counting: while (n < 6) {
+    n += 1;
+    for (k in [1, 2]) {
+        if (n % 2 == 0) {
+            skipped += 1;
+            continue counting;
+        }
+    }
+    print n;
+}
+print skipped;
+
+for (i in 0..2) {
+    inner: for (j in 0..5) {
+        if (j > i) {
+            break;
+        }
+        print "${i}-${j}";
+    }
+}
@@ -0,0 +1,72 @@
+fn each(items, action) {
+    for (item in items) {
+        action(item);
+    }
+}
+
+fn count(items, keep) {
+    let result = 0;
+    for (item in items) {
+        if (keep(item)) {
+            result += 1;
+        }
+    }
+    return result;
+}
+
+fn reduce(items, combine, initial) {
+    let result = initial;
+    for (item in items) {
+        result = combine(result, item);
+    }
+    return result;
+}
+
+let numbers = [1, 2, 3, 4, 5];
+each(numbers, (x) => { print x * 2; });
+print count(numbers, (x) => x % 2 == 1);
+print reduce(numbers, (total, x) => total + x, 0);
+
+let greet = () => "hello";
+print greet();
+
+let describe = (name, greeting = "Hi", ...rest) => {
+    if (len(rest) > 0) {
+        return "${greeting} ${name} and ${len(rest)} more";
+    }
+    return "${greeting} ${name}";
+};
+print describe("Ada");
+print describe("Ada", "Hey", "Grace", "Alan");
+
+let adder = (a) => (b) => a + b;
+print adder(2)(3);
+
+print (1 + 2) * 3;
+print ((x) => x * x)(7);
+
+print match (4) {
+    n if (n > 3) => "big",
+    _ => (x) => x,
+};
+
+let y = 3;
+fn classify(value) {
+    return match (value) {
+        n if n > (y) => "above ${y}",
+        n if (n > 0) && (n < 10) => "small",
+        n if ((x) => x < 0)(n) => "negative",
+        _ => "zero",
+    };
+}
+print classify(5);
+print classify(2);
+print classify(-1);
+print classify(0);
+
+fn magnitudes(values) {
+    let abs = (x) => x > 0 ? x : -x;
+    let sign = (n) => n == 0 ? "zero" : match (n) { n if n > 0 => "positive", _ => "negative" };
+    return "${abs(values[0])} ${abs(values[1])} ${sign(values[1])}";
+}
+print magnitudes([4, -7]);
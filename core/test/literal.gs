@@ -0,0 +1,13 @@
+print 0xFF;
+print 0Xff;
+print 0b1010;
+print 0o755;
+print 1_000_000;
+print 0xFF_FF;
+print 6.02e23;
+print 1e3;
+print 2.5E-3;
+print 1_0.0_1;
+print 0o755 & 0o077;
+print 0b1 << 0x4;
+print 007;
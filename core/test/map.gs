@@ -0,0 +1,49 @@
+let config = {"name": "gscript", "version": 1, 2: "two"};
+print config;
+print config["name"];
+print config[2];
+
+config["version"] = config["version"] + 1;
+config["debug"] = false;
+print config;
+print len(config);
+
+print has(config, "debug");
+print delete(config, "debug");
+print has(config, "debug");
+print delete(config, "debug");
+
+let names = keys(config);
+let i = 0;
+while (i < len(names)) {
+  print names[i];
+  i = i + 1;
+}
+
+print {};
+
+let numbers = {};
+numbers[2 ** 70] = "big";
+numbers[1.50m] = "decimal";
+numbers[3.0m] = "three";
+print numbers[2 ** 70];
+print numbers[1.5m];
+print numbers[3];
+print has(numbers, 2 ** 71);
+print numbers;
+print delete(numbers, 2 ** 70);
+print keys(numbers);
+
+let mixed = {};
+mixed[1.5m] = "decimal";
+mixed[1.5] = "float";
+print mixed;
+print len(mixed);
+print mixed[1.50m];
+mixed[2 ** 70] = "big";
+print has(mixed, 1180591620717411303424.0);
+print mixed[1180591620717411303424.00m];
+mixed[20.0m] = "twenty";
+print mixed[20];
+print has(mixed, 0.1);
+print config["missing"];
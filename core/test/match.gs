@@ -0,0 +1,75 @@
+fn describe(value) {
+    return match (value) {
+        0 => "zero",
+        1 | 2 | 3 => "small",
+        -1 => "minus one",
+        "a" | "b" => "letter",
+        true => "yes",
+        null => "nothing",
+        n if n > 100 => "big ${n}",
+        _ => "other",
+    };
+}
+
+print describe(0);
+print describe(2);
+print describe(-1);
+print describe("b");
+print describe(true);
+print describe(null);
+print describe(1000);
+print describe(50);
+print describe(2.0);
+
+struct Point {
+    fn init(x, y) {
+        this.x = x;
+        this.y = y;
+    }
+}
+
+struct Point3 < Point {
+    fn init(x, y, z) {
+        super.init(x, y);
+        this.z = z;
+    }
+}
+
+struct Circle {
+    fn init(radius) {
+        this.radius = radius;
+    }
+}
+
+fn where(shape) {
+    return match (shape) {
+        Point3{x, y, z: 0} => "flat point at ${x}, ${y}",
+        Point{x: 0, y: 0} => "origin",
+        Point{x, y} if x > 0 && y > 0 => "first quadrant ${x}, ${y}",
+        Point{x, y: 0} => "on the x axis at ${x}",
+        Point{} => "somewhere",
+        Circle{radius} => "circle of ${radius}",
+        _ => "unknown",
+    };
+}
+
+print where(Point(0, 0));
+print where(Point(1, 2));
+print where(Point(-3, 0));
+print where(Point(-3, -3));
+print where(Point3(4, 5, 0));
+print where(Point3(-4, -5, 1));
+print where(Circle(2));
+print where("point");
+
+let x = "outer";
+print match (Point(7, 8)) { Point{x} => x };
+print x;
+
+try {
+    print match ("c") { "a" | "b" => "letter" };
+} catch (e) {
+    print "${e.kind} on line ${e.line}: ${e.message}";
+}
+
+print match (5) { 1 => "one" };
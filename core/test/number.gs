@@ -0,0 +1,17 @@
+print 7 / 2;
+print 7.0 / 2;
+print 7 / 2.0;
+print 1 + 2;
+print 1 + 2.5;
+print 3.0;
+print 0.1 + 0.2;
+print 2 ** 62;
+print 9007199254740993;
+print 9007199254740993 - 1;
+print 1 == 1.0;
+print 2 > 1.5;
+print -7 % 3;
+print -7 ~/ 2;
+print [1, 2.0, null, "s"];
+print {1: "int", 1.0: "float"};
+print 10 / 0;
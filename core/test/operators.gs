@@ -0,0 +1,19 @@
+print 17 % 5;
+print -17 % 5;
+print 2 ** 10;
+print 2 ** 3 ** 2;
+print -2 ** 2;
+print 2 ** -1;
+// Integer division is spelled ~/ because // starts a comment.
+print 7 ~/ 2;
+print -7 ~/ 2;
+print 6 & 3;
+print 6 | 3;
+print 6 ^ 3;
+print ~5;
+print 1 << 4;
+print 256 >> 2;
+print 1 | 2 ^ 3 & 4 << 1;
+print 1 + 2 << 1;
+print 3 * 4 % 5;
+print 1.5 & 1;
@@ -0,0 +1,56 @@
+for (i in 0..5) {
+    print i;
+}
+for (i in 1..=10 step 3) {
+    print i;
+}
+for (i in 5..0 step -2) {
+    print i;
+}
+for (i in 3..3) {
+    print "never";
+}
+
+print 0..10;
+print 0..=10 step 2;
+print len(0..10);
+print len(0..=10 step 3);
+print len(10..0);
+print len(0..1000000000);
+
+let huge = 0..1000000000;
+let count = 0;
+for (i in huge) {
+    if (i == 3) {
+        break;
+    }
+    count += 1;
+}
+print count;
+
+print 3 in 0..5;
+print 5 in 0..5;
+print 5 in 0..=5;
+print 4 in 0..10 step 2;
+print 5 in 0..10 step 2;
+print 2.5 in 0..5;
+print "b" in ["a", "b"];
+print "c" in {"a": 1};
+print "ell" in "hello";
+
+let letters = ["a", "b", "c", "d", "e"];
+print letters[1..3];
+print letters[0..=len(letters) - 1 step 2];
+print letters[4..=0 step -1];
+print "héllo wörld"[6..11];
+
+let from = 2;
+print letters[from..from + 2];
+
+let total = 0;
+for (n in 1..=100) {
+    total += n;
+}
+print total;
+
+print letters[3..10];
@@ -0,0 +1,54 @@
+struct Point {
+  static let dimensions = 2;
+  static let created = 0;
+
+  fn init(x, y) {
+    this.x = x;
+    this.y = y;
+    Point.created = Point.created + 1;
+  }
+
+  static fn origin() {
+    return Point(0, 0);
+  }
+
+  static fn describe() {
+    print "Point in " + this.name() + " dimensions";
+  }
+
+  static fn name() {
+    return "two";
+  }
+}
+
+print Point.dimensions;
+let o = Point.origin();
+print o.x;
+print o.y;
+Point(1, 2);
+print Point.created;
+Point.describe();
+
+struct Pixel < Point {
+  static let color = "red";
+}
+
+print Pixel.color;
+print Pixel.dimensions;
+print Pixel.origin().x;
+
+struct Voxel < Point {
+  static fn name() {
+    return "three, not " + super.name();
+  }
+
+  static fn origin() {
+    let point = super.origin();
+    return point.x;
+  }
+}
+
+print Voxel.name();
+print Voxel.origin();
+
+print Point.color;
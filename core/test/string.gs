@@ -0,0 +1,10 @@
+print "line\ttab";
+print "first\nsecond";
+print "say \"hi\"";
+print "back\\slash";
+print "café";
+print "\u{1F600}";
+print `raw \n "text"
+spans lines`;
+print len(`a
+b`);
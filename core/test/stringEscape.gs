@@ -0,0 +1,3 @@
+print "escapes are checked";
+print "tab\there";
+print "bad \q escape";
@@ -0,0 +1,9 @@
+let café = "crème brûlée";
+print café;
+print len(café);
+let 名前 = "世界";
+print "こんにちは ${名前}";
+let π = 3.14;
+print π * 2;
+print len("😀👍");
+print "naïve" + " résumé";
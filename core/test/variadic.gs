@@ -0,0 +1,43 @@
+fn log(level, ...parts) {
+    let message = "";
+    for (part in parts) {
+        message += " ${part}";
+    }
+    return "[${level}]${message} (${len(parts)} parts)";
+}
+
+print log("info");
+print log("info", "server", "started");
+print log(level: "warn");
+
+let details = ["disk", "almost", "full"];
+print log("error", ...details);
+print log(...details);
+print log("debug", 1, ...0..3, "end");
+
+fn sum(...numbers) {
+    let total = 0;
+    for (n in numbers) {
+        total += n;
+    }
+    return total;
+}
+print sum();
+print sum(...1..=100);
+
+fn point(x, y = 0) {
+    return "${x}, ${y}";
+}
+print point(...[1, 2]);
+print point(...[5]);
+
+struct Bag {
+    fn init(name, ...items) {
+        this.name = name;
+        this.items = items;
+    }
+}
+let bag = Bag("groceries", "milk", "eggs");
+print bag.items;
+
+print sum(...0..300);
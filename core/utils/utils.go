@@ -53,6 +53,9 @@ func AsFloat(value any) (error, float64) {
 	if floatValue, ok := value.(float64); ok {
 		return nil, floatValue
 	}
+	if integerValue, ok := value.(int64); ok {
+		return nil, float64(integerValue)
+	}
 	return NewError("Unexpected value type for float cast '%T'", value), 0.0
 }
 
@@ -60,7 +63,7 @@ func AsString(value any) string {
 	if stringValue, ok := value.(string); ok {
 		return stringValue
 	}
-	return fmt.Sprint("%v", value)
+	return fmt.Sprintf("%v", value)
 }
 
 func IsString(value any) bool {